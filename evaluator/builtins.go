@@ -1,133 +1,192 @@
 package evaluator
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/manishmeganathan/tunalang/object"
 )
 
-var builtins = map[string]*object.Builtin{
-	"len": {
-		Fn: func(args ...object.Object) object.Object {
-
-			if len(args) != 1 {
-				return object.NewError("wrong number of arguments. got=%d, want=1",
-					len(args))
-			}
-
-			switch arg := args[0].(type) {
-			// List objects
-			case *object.List:
-				return &object.Integer{Value: int64(len(arg.Elements))}
-
-			// String objects
-			case *object.String:
-				return &object.Integer{Value: int64(len(arg.Value))}
-
-			// Everything else
-			default:
-				return object.NewError("argument to `len` not supported, got %s",
-					args[0].Type())
-			}
+// A function that generates and returns the default
+// built-in functions for a given Interpreter
+func newBuiltins(in *Interpreter) map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"len": {
+			Fn: func(args ...object.Object) object.Object {
+
+				if len(args) != 1 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				switch arg := args[0].(type) {
+				// List objects
+				case *object.List:
+					return &object.Integer{Value: int64(len(arg.Elements))}
+
+				// String objects (the length is the number of characters)
+				case *object.String:
+					return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
+
+				// Everything else
+				default:
+					return object.NewErrorOfKind(object.TYPE_ERROR, "argument to `len` not supported, got %s",
+						args[0].Type())
+				}
+			},
 		},
-	},
-	"puts": {
-		Fn: func(args ...object.Object) object.Object {
-			for _, arg := range args {
-				fmt.Println(arg.Inspect())
-			}
-
-			return NULL
+		"str": {
+			Fn: func(args ...object.Object) object.Object {
+
+				if len(args) != 1 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				return in.allocate(&object.String{Value: object.Str(args[0])})
+			},
+		},
+		"puts": {
+			Fn: func(args ...object.Object) object.Object {
+				for _, arg := range args {
+					fmt.Fprintln(in.Stdout, arg.Inspect())
+				}
+
+				return NULL
+			},
+		},
+		"eputs": {
+			Fn: func(args ...object.Object) object.Object {
+				for _, arg := range args {
+					fmt.Fprintln(in.Stderr, arg.Inspect())
+				}
+
+				return NULL
+			},
+		},
+		"gets": {
+			Fn: func(args ...object.Object) object.Object {
+
+				if len(args) != 0 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=0",
+						len(args))
+				}
+
+				in.stdinMu.Lock()
+				line, err := in.stdinReader().ReadString('\n')
+				in.stdinMu.Unlock()
+
+				if err != nil && (err != io.EOF || line == "") {
+					return NULL
+				}
+
+				return in.allocate(&object.String{Value: strings.TrimRight(line, "\r\n")})
+			},
 		},
-	},
-	"first": {
-		Fn: func(args ...object.Object) object.Object {
-
-			if len(args) != 1 {
-				return object.NewError("wrong number of arguments. got=%d, want=1",
-					len(args))
-			}
-
-			if args[0].Type() != object.LIST_OBJ {
-				return object.NewError("argument to `first` must be LIST, got %s",
-					args[0].Type())
-			}
-
-			list := args[0].(*object.List)
-			if len(list.Elements) > 0 {
-				return list.Elements[0]
-			}
-
-			return NULL
+		"first": {
+			Fn: func(args ...object.Object) object.Object {
+
+				if len(args) != 1 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				if args[0].Type() != object.LIST_OBJ {
+					return object.NewErrorOfKind(object.TYPE_ERROR, "argument to `first` must be LIST, got %s",
+						args[0].Type())
+				}
+
+				list := args[0].(*object.List)
+				if len(list.Elements) > 0 {
+					return list.Elements[0]
+				}
+
+				return NULL
+			},
 		},
-	},
-	"last": {
-		Fn: func(args ...object.Object) object.Object {
-
-			if len(args) != 1 {
-				return object.NewError("wrong number of arguments. got=%d, want=1",
-					len(args))
-			}
-
-			if args[0].Type() != object.LIST_OBJ {
-				return object.NewError("argument to `last` must be LIST, got %s",
-					args[0].Type())
-			}
-
-			list := args[0].(*object.List)
-			length := len(list.Elements)
-			if len(list.Elements) > 0 {
-				return list.Elements[length-1]
-			}
-
-			return NULL
+		"last": {
+			Fn: func(args ...object.Object) object.Object {
+
+				if len(args) != 1 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				if args[0].Type() != object.LIST_OBJ {
+					return object.NewErrorOfKind(object.TYPE_ERROR, "argument to `last` must be LIST, got %s",
+						args[0].Type())
+				}
+
+				list := args[0].(*object.List)
+				length := len(list.Elements)
+				if len(list.Elements) > 0 {
+					return list.Elements[length-1]
+				}
+
+				return NULL
+			},
 		},
-	},
-	"tail": {
-		Fn: func(args ...object.Object) object.Object {
-
-			if len(args) != 1 {
-				return object.NewError("wrong number of arguments. got=%d, want=1",
-					len(args))
-			}
-
-			if args[0].Type() != object.LIST_OBJ {
-				return object.NewError("argument to `tail` must be LIST, got %s",
-					args[0].Type())
-			}
-
-			list := args[0].(*object.List)
-			length := len(list.Elements)
-			if length > 0 {
-				newElements := make([]object.Object, length-1)
-				copy(newElements, list.Elements[1:length])
-				return &object.List{Elements: newElements}
-			}
-
-			return NULL
+		"tail": {
+			Fn: func(args ...object.Object) object.Object {
+
+				if len(args) != 1 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				if args[0].Type() != object.LIST_OBJ {
+					return object.NewErrorOfKind(object.TYPE_ERROR, "argument to `tail` must be LIST, got %s",
+						args[0].Type())
+				}
+
+				list := args[0].(*object.List)
+				length := len(list.Elements)
+				if length > 0 {
+					newElements := make([]object.Object, length-1)
+					copy(newElements, list.Elements[1:length])
+					return in.allocate(&object.List{Elements: newElements})
+				}
+
+				return NULL
+			},
 		},
-	},
-	"push": {
-		Fn: func(args ...object.Object) object.Object {
+		"push": {
+			Fn: func(args ...object.Object) object.Object {
 
-			if len(args) != 2 {
-				return object.NewError("wrong number of arguments. got=%d, want=1",
-					len(args))
-			}
+				if len(args) != 2 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
 
-			if args[0].Type() != object.LIST_OBJ {
-				return object.NewError("argument to `push` must be LIST, got %s",
-					args[0].Type())
-			}
+				if args[0].Type() != object.LIST_OBJ {
+					return object.NewErrorOfKind(object.TYPE_ERROR, "argument to `push` must be LIST, got %s",
+						args[0].Type())
+				}
 
-			list := args[0].(*object.List)
-			length := len(list.Elements)
+				list := args[0].(*object.List)
+				length := len(list.Elements)
 
-			newElements := make([]object.Object, length+1)
-			copy(newElements, list.Elements)
-			newElements[length] = args[1]
+				newElements := make([]object.Object, length+1)
+				copy(newElements, list.Elements)
+				newElements[length] = args[1]
 
-			return &object.List{Elements: newElements}
+				return in.allocate(&object.List{Elements: newElements})
+			},
 		},
-	},
+	}
+}
+
+// A method of Interpreter that returns the buffered reader for its standard input
+func (in *Interpreter) stdinReader() *bufio.Reader {
+	// Check if the reader needs to be (re)created for the current input
+	if in.reader == nil || in.readerSource != in.Stdin {
+		in.reader = bufio.NewReader(in.Stdin)
+		in.readerSource = in.Stdin
+	}
+
+	// Return the buffered reader
+	return in.reader
 }
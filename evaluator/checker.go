@@ -0,0 +1,378 @@
+package evaluator
+
+import (
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// Represents the directive that enables strict mode for a program when it is its first statement
+const strictDirective = "use strict"
+
+// A structure that represents a lexical scope of the static checker
+type checkScope struct {
+	// Represents the names declared in the scope
+	names map[string]bool
+
+	// Represents the enclosing scope (nil for the program scope)
+	outer *checkScope
+}
+
+// A structure that represents a function literal whose body is checked after the enclosing
+// program such that it can refer to names that are declared after the function is created
+type pendingFunction struct {
+	// Represents the function literal
+	fn *syntaxtree.FunctionLiteral
+
+	// Represents the scope in which the function literal is created
+	scope *checkScope
+}
+
+// A structure that represents the static checker of strict mode. The checker reports the
+// redeclaration of a name in the same scope and the reads of names that are not declared.
+type checker struct {
+	// Represents the interpreter whose built-in functions are declared names
+	in *Interpreter
+
+	// Represents the environment in which the program is evaluated
+	env *object.Environment
+
+	// Represents the function literals that are yet to be checked
+	pending []pendingFunction
+
+	// Represents the diagnostics reported by the checker
+	errors []*object.Error
+}
+
+// A method of Interpreter that statically checks a program before it is evaluated in the
+// given environment and returns the diagnostics of strict mode. A name is declared if it is
+// bound by a preceding let or const statement, a parameter, a built-in or the environment.
+func (in *Interpreter) Check(program *syntaxtree.Program, env *object.Environment) []*object.Error {
+	// Create a checker for the program
+	c := &checker{in: in, env: env}
+	// Create the scope of the program
+	scope := &checkScope{names: make(map[string]bool)}
+
+	// Check the statements of the program
+	c.checkStatements(program.Statements, scope)
+
+	// Check the bodies of the function literals, which may add more function literals
+	for len(c.pending) > 0 {
+		next := c.pending[0]
+		c.pending = c.pending[1:]
+		c.checkFunction(next.fn, next.scope)
+	}
+
+	// Return the diagnostics
+	return c.errors
+}
+
+// A method of Interpreter that returns whether a program is evaluated in strict mode,
+// which is enabled by the interpreter option or by a "use strict" directive
+func (in *Interpreter) isStrictProgram(program *syntaxtree.Program) bool {
+	// Check the interpreter option
+	if in.Strict {
+		return true
+	}
+
+	// Check if the first statement is the strict mode directive
+	if len(program.Statements) > 0 {
+		if stmt, ok := program.Statements[0].(*syntaxtree.ExpressionStatement); ok {
+			if str, ok := stmt.Expression.(*syntaxtree.StringLiteral); ok {
+				return str.Value == strictDirective
+			}
+		}
+	}
+
+	return false
+}
+
+// A method of checker that checks a sequence of statements in a scope
+func (c *checker) checkStatements(statements []syntaxtree.Statement, scope *checkScope) {
+	// Declare the hoisted function declarations
+	for _, statement := range statements {
+		if export, ok := statement.(*syntaxtree.ExportStatement); ok {
+			statement = export.Declaration
+		}
+		if declaration, ok := statement.(*syntaxtree.FunctionStatement); ok {
+			c.declare(declaration.Name, scope)
+		}
+	}
+
+	// Iterate over the statements
+	for _, statement := range statements {
+		c.check(statement, scope)
+	}
+}
+
+// A method of checker that checks the body of a function literal
+// in a new scope with its parameters, given the enclosing scope
+func (c *checker) checkFunction(fn *syntaxtree.FunctionLiteral, outer *checkScope) {
+	// Create the scope of the function
+	scope := &checkScope{names: make(map[string]bool), outer: outer}
+
+	// Declare the parameters of the function
+	for _, param := range fn.Parameters {
+		c.declare(param, scope)
+	}
+
+	// Check the body of the function in the scope of its parameters
+	c.checkStatements(fn.Body.Statements, scope)
+}
+
+// A method of checker that recursively checks a Syntax Tree node in a scope
+func (c *checker) check(node syntaxtree.Node, scope *checkScope) {
+	// Check the type of Syntax Tree Node
+	switch node := node.(type) {
+
+	// Let Statement Node
+	case *syntaxtree.LetStatement:
+		// Check the value before declaring the names
+		c.check(node.Value, scope)
+		if node.Pattern != nil {
+			c.declarePattern(node.Pattern, scope)
+		} else {
+			c.declare(node.Name, scope)
+		}
+
+	// Import Statement Node
+	case *syntaxtree.ImportStatement:
+		c.declare(node.Name, scope)
+
+	// Function Statement Node (the name is declared when the statements are hoisted)
+	case *syntaxtree.FunctionStatement:
+		c.pending = append(c.pending, pendingFunction{fn: node.Function, scope: scope})
+
+	// Struct Statement Node
+	case *syntaxtree.StructStatement:
+		c.declare(node.Name, scope)
+
+		// Defer the check of the methods in a scope where self is declared
+		methodScope := &checkScope{names: map[string]bool{selfName: true}, outer: scope}
+		for _, method := range node.Methods {
+			c.pending = append(c.pending, pendingFunction{fn: method.Function, scope: methodScope})
+		}
+
+	// Enum Statement Node
+	case *syntaxtree.EnumStatement:
+		c.declare(node.Name, scope)
+
+	// Export Statement Node
+	case *syntaxtree.ExportStatement:
+		c.check(node.Declaration, scope)
+
+	// Return Statement Node
+	case *syntaxtree.ReturnStatement:
+		c.check(node.ReturnValue, scope)
+
+	// Throw Statement Node
+	case *syntaxtree.ThrowStatement:
+		c.check(node.Value, scope)
+
+	// Expression Statement Node
+	case *syntaxtree.ExpressionStatement:
+		c.check(node.Expression, scope)
+
+	// Block Statement Node
+	case *syntaxtree.BlockStatement:
+		// Check the statements in a new scope
+		c.checkStatements(node.Statements, &checkScope{names: make(map[string]bool), outer: scope})
+
+	// Prefix Expression Node
+	case *syntaxtree.PrefixExpression:
+		c.check(node.Right, scope)
+
+	// Infix Expression Node
+	case *syntaxtree.InfixExpression:
+		c.check(node.Left, scope)
+		c.check(node.Right, scope)
+
+	// If Expression Node
+	case *syntaxtree.IfExpression:
+		c.check(node.Condition, scope)
+		c.check(node.Consequence, scope)
+		if node.Alternative != nil {
+			c.check(node.Alternative, scope)
+		}
+
+	// Try Expression Node
+	case *syntaxtree.TryExpression:
+		c.check(node.Block, scope)
+
+		// Check the catch block in the scope of the caught value
+		if node.Catch != nil {
+			catchScope := &checkScope{names: make(map[string]bool), outer: scope}
+			c.declare(node.Parameter, catchScope)
+			c.checkStatements(node.Catch.Statements, catchScope)
+		}
+
+		if node.Finally != nil {
+			c.check(node.Finally, scope)
+		}
+
+	// Match Expression Node
+	case *syntaxtree.MatchExpression:
+		c.check(node.Subject, scope)
+
+		// Check each arm in the scope of the bindings of its pattern
+		for _, arm := range node.Arms {
+			armScope := &checkScope{names: make(map[string]bool), outer: scope}
+			c.declarePattern(arm.Pattern, armScope)
+			if arm.Guard != nil {
+				c.check(arm.Guard, armScope)
+			}
+			c.checkStatements(arm.Body.Statements, armScope)
+		}
+
+	// Call Expression Node
+	case *syntaxtree.CallExpression:
+		// Check only the unquoted expressions of quote calls (the quoted node is not evaluated)
+		if isSpecialForm(node, quoteName) {
+			for _, arg := range node.Arguments {
+				c.checkUnquoted(arg, scope)
+			}
+			break
+		}
+
+		c.check(node.Function, scope)
+		for _, arg := range node.Arguments {
+			c.check(arg, scope)
+		}
+
+	// Index Expression Node
+	case *syntaxtree.IndexExpression:
+		c.check(node.Left, scope)
+		c.check(node.Index, scope)
+
+	// Member Expression Node
+	case *syntaxtree.MemberExpression:
+		c.check(node.Object, scope)
+
+	// Slice Expression Node
+	case *syntaxtree.SliceExpression:
+		c.check(node.Left, scope)
+		for _, bound := range []syntaxtree.Expression{node.Start, node.End, node.Step} {
+			if bound != nil {
+				c.check(bound, scope)
+			}
+		}
+
+	// Grouped Expression Node
+	case *syntaxtree.GroupedExpression:
+		c.check(node.Expression, scope)
+
+	// Interpolated String Literal Node
+	case *syntaxtree.InterpolatedString:
+		for _, exp := range node.Expressions {
+			c.check(exp, scope)
+		}
+
+	// List Literal Node
+	case *syntaxtree.ListLiteral:
+		for _, element := range node.Elements {
+			c.check(element, scope)
+		}
+
+	// Map Literal Node
+	case *syntaxtree.MapLiteral:
+		for key, value := range node.Pairs {
+			c.check(key, scope)
+			c.check(value, scope)
+		}
+
+	// Function Literal Node
+	case *syntaxtree.FunctionLiteral:
+		// Defer the check of the body until the enclosing declarations are known
+		c.pending = append(c.pending, pendingFunction{fn: node, scope: scope})
+
+	// Identifier Literal Node
+	case *syntaxtree.Identifier:
+		c.resolve(node, scope)
+	}
+}
+
+// A method of checker that checks the arguments of the unquote calls within a quoted node
+func (c *checker) checkUnquoted(node syntaxtree.Node, scope *checkScope) {
+	syntaxtree.Modify(node, func(node syntaxtree.Node) syntaxtree.Node {
+		if isSpecialForm(node, unquoteName) {
+			for _, arg := range node.(*syntaxtree.CallExpression).Arguments {
+				c.check(arg, scope)
+			}
+		}
+
+		return node
+	})
+}
+
+// A method of checker that declares a name in a scope and reports a redeclaration if the name is
+// already declared in the same scope by the program. The names bound in the environment (such as by
+// an earlier run of the program or an earlier line of a REPL) may be declared again by the program.
+func (c *checker) declare(ident *syntaxtree.Identifier, scope *checkScope) {
+	// Check if the name is declared in the scope
+	if scope.names[ident.Value] {
+		c.report(object.NewErrorOfKind(object.DECLARATION_ERROR, "identifier already declared in this scope: %s", ident.Value), ident)
+		return
+	}
+
+	// Declare the name
+	scope.names[ident.Value] = true
+}
+
+// A method of checker that declares the names bound by a pattern in a scope
+// and checks the default values of the pattern before the names they bind
+func (c *checker) declarePattern(pattern syntaxtree.Pattern, scope *checkScope) {
+	// Check the type of the pattern
+	switch pattern := pattern.(type) {
+
+	// Binding Pattern
+	case *syntaxtree.BindingPattern:
+		c.declare(pattern.Name, scope)
+
+	// Default Pattern
+	case *syntaxtree.DefaultPattern:
+		c.check(pattern.Default, scope)
+		c.declarePattern(pattern.Pattern, scope)
+
+	// List Pattern
+	case *syntaxtree.ListPattern:
+		for _, element := range pattern.Elements {
+			c.declarePattern(element, scope)
+		}
+		if pattern.Rest != nil {
+			c.declare(pattern.Rest, scope)
+		}
+
+	// Map Pattern
+	case *syntaxtree.MapPattern:
+		for _, value := range pattern.Values {
+			c.declarePattern(value, scope)
+		}
+	}
+}
+
+// A method of checker that resolves a read of a name in a scope
+// and reports the read if the name has not been declared
+func (c *checker) resolve(ident *syntaxtree.Identifier, scope *checkScope) {
+	// Search the name in the scope and its enclosing scopes
+	for current := scope; current != nil; current = current.outer {
+		if current.names[ident.Value] {
+			return
+		}
+	}
+
+	// Search the name in the environment and the built-in functions
+	if _, ok := c.env.Get(ident.Value); ok {
+		return
+	}
+	if _, ok := c.in.builtins[ident.Value]; ok {
+		return
+	}
+
+	// Report the undeclared name
+	c.report(object.NewErrorOfKind(object.NAME_ERROR, "identifier not declared: %s", ident.Value), ident)
+}
+
+// A method of checker that reports a diagnostic at the position of an identifier
+func (c *checker) report(err *object.Error, ident *syntaxtree.Identifier) {
+	err.Line, err.Column = ident.Token.Line, ident.Token.Column
+	c.errors = append(c.errors, err)
+}
@@ -1,30 +1,65 @@
 package evaluator
 
 import (
+	"github.com/manishmeganathan/tunalang/lexer"
 	"github.com/manishmeganathan/tunalang/object"
 	"github.com/manishmeganathan/tunalang/syntaxtree"
 )
 
+// The native Null and Boolean objects (aliases of the immutable object singletons)
 var (
-	NULL  = &object.Null{}
-	TRUE  = &object.Boolean{Value: true}
-	FALSE = &object.Boolean{Value: false}
+	NULL  = object.NULL
+	TRUE  = object.TRUE
+	FALSE = object.FALSE
 )
 
-// A function that evaluates a Syntax Tree given a node
-// on it and returns an evaluated object
+// A function that evaluates a Syntax Tree given a node on it and returns an
+// evaluated object. It is a compatibility wrapper that uses the default Interpreter.
 func Evaluate(node syntaxtree.Node, env *object.Environment) object.Object {
+	return defaultInterpreter.Evaluate(node, env)
+}
+
+// A structure that represents the result of an optional chain that was short-circuited by a null
+// value before a '?.' access. It is passed through the accesses and calls that continue the chain
+// and becomes NULL where the chain ends, such that it is never observed by a program.
+type shortCircuit struct{}
+
+// A method of shortCircuit that returns the Null value type
+func (s *shortCircuit) Type() object.ObjectType { return object.NULL_OBJ }
+
+// A method of shortCircuit that returns the string value of the Null
+func (s *shortCircuit) Inspect() string { return "null" }
+
+// Represents the result of a short-circuited optional chain
+var shortCircuited object.Object = &shortCircuit{}
+
+// A method of Interpreter that recursively evaluates a Syntax
+// Tree given a node on it and returns an evaluated object
+func (in *Interpreter) eval(node syntaxtree.Node, env *object.Environment) object.Object {
+	// Evaluate the node and end the optional chain that it may be part of
+	result := in.evalChain(node, env)
+	if result == shortCircuited {
+		return NULL
+	}
+
+	return result
+}
+
+// A method of Interpreter that evaluates a Syntax Tree node that may be part of an optional
+// chain. The operand of an access or call is evaluated with it, such that a short-circuited
+// chain skips the remaining accesses and calls of the chain instead of applying them to null.
+func (in *Interpreter) evalChain(node syntaxtree.Node, env *object.Environment) object.Object {
 	// Check the type of Syntax Tree Node
 	switch node := node.(type) {
 	// Program Node (Tree Root)
 	case *syntaxtree.Program:
 		// Evaluate the statements in the program
-		return evalProgram(node, env)
+		return in.evalProgram(node, env)
 
 	// Return Statement Node
 	case *syntaxtree.ReturnStatement:
 		// Evaluate the Expression in the return statement
-		val := Evaluate(node.ReturnValue, env)
+		val := in.eval(node.ReturnValue, env)
 		// Check if evaluated value is an error
 		if isError(val) {
 			// Return the error
@@ -34,29 +69,83 @@ func Evaluate(node syntaxtree.Node, env *object.Environment) object.Object {
 		// Return the evaluated return object
 		return &object.ReturnValue{Value: val}
 
-		// Let Statement Node
+	// Throw Statement Node
+	case *syntaxtree.ThrowStatement:
+		// Evaluate the Expression in the throw statement
+		val := in.eval(node.Value, env)
+		// Check if evaluated value is an error
+		if isError(val) {
+			// Return the error
+			return val
+		}
+
+		// Return the thrown error object
+		return locateError(newThrownError(val), node.Token)
+
+	// Let Statement Node
 	case *syntaxtree.LetStatement:
 		// Evaluate the Expression in the let statement
-		val := Evaluate(node.Value, env)
+		val := in.eval(node.Value, env)
 		// Check if evaluated value is an error
 		if isError(val) {
 			// Return the error
 			return val
 		}
 
+		// Check if the statement destructures the value
+		if node.Pattern != nil {
+			// Bind the names of the pattern to the environment store
+			return locateError(in.destructure(node.Pattern, val, env, node.IsConst()), node.Token)
+		}
+
 		// Set the evaluated object and the literal
 		// name to the environment store
-		env.Set(node.Name.Value, val)
+		var result object.Object
+		if node.IsConst() {
+			result = env.SetConst(node.Name.Value, val)
+		} else {
+			result = env.Set(node.Name.Value, val)
+		}
+
+		// Check if the binding failed (the name is a constant)
+		if isError(result) {
+			// Return the error
+			return locateError(result, node.Name.Token)
+		}
+
+	// Import Statement Node
+	case *syntaxtree.ImportStatement:
+		// Import the module and bind it in the environment
+		return in.evalImportStatement(node, env)
+
+	// Function Statement Node (declared functions are hoisted by the enclosing block or program)
+	case *syntaxtree.FunctionStatement:
+		return nil
+
+	// Struct Statement Node
+	case *syntaxtree.StructStatement:
+		// Declare the struct and bind it in the environment
+		return evalStructStatement(node, env)
+
+	// Enum Statement Node
+	case *syntaxtree.EnumStatement:
+		// Declare the enum and bind it in the environment
+		return evalEnumStatement(node, env)
+
+	// Export Statement Node (exports are evaluated by evalProgram)
+	case *syntaxtree.ExportStatement:
+		// Return Error
+		return locateError(object.NewErrorOfKind(object.IMPORT_ERROR, "export is only allowed at the top level of a module"), node.Token)
 
 	// Expression Node
 	case *syntaxtree.ExpressionStatement:
 		// Recursive evaluation
-		return Evaluate(node.Expression, env)
+		return in.eval(node.Expression, env)
 
 	// Prefix Expression Node
 	case *syntaxtree.PrefixExpression:
 		// Evaluate the expression into an object
-		right := Evaluate(node.Right, env)
+		right := in.eval(node.Right, env)
 		// Check if evaluated value is an error
 		if isError(right) {
 			// Return the error
@@ -64,20 +153,29 @@ func Evaluate(node syntaxtree.Node, env *object.Environment) object.Object {
 		}
 
 		// Evaluate the object for the operator
-		return evalPrefixExpression(node.Operator, right)
+		return locateError(evalPrefixExpression(node.Operator, right), node.Token)
 
 	// Infix Expression Node
 	case *syntaxtree.InfixExpression:
 		// Evaluate the left node
-		left := Evaluate(node.Left, env)
+		left := in.eval(node.Left, env)
 		// Check if evaluated left value is an error
 		if isError(left) {
 			// Return the error
 			return left
 		}
 
+		// Check if the operator is the null-coalescing operator
+		if node.Operator == "??" {
+			// Evaluate the right node only if the left value is null
+			if left != NULL {
+				return left
+			}
+			return in.eval(node.Right, env)
+		}
+
 		// Evaluate the right node
-		right := Evaluate(node.Right, env)
+		right := in.eval(node.Right, env)
 		// Check if evaluated right value is an error
 		if isError(right) {
 			// Return the error
@@ -85,30 +183,45 @@ func Evaluate(node syntaxtree.Node, env *object.Environment) object.Object {
 		}
 
 		// Evaluate the expression with the objects and the operator
-		return evalInfixExpression(node.Operator, left, right)
+		return locateError(in.evalInfixExpression(node.Operator, left, right), node.Token)
 
 	// Block Statement Node
 	case *syntaxtree.BlockStatement:
-		// Evaluate the statements in the block
-		return evalBlockStatement(node, env)
+		// Evaluate the statements in the block in a new scope
+		return in.evalBlockStatement(node, object.NewEnclosedEnvironment(env))
 
 	// If Expression Node
 	case *syntaxtree.IfExpression:
 		// Evaluate the if expression
-		return evalIfExpression(node, env)
+		return in.evalIfExpression(node, env)
+
+	// Try Expression Node
+	case *syntaxtree.TryExpression:
+		// Evaluate the try expression
+		return in.evalTryExpression(node, env)
+
+	// Match Expression Node
+	case *syntaxtree.MatchExpression:
+		// Evaluate the match expression
+		return in.evalMatchExpression(node, env)
 
 	// Call Expression Node
 	case *syntaxtree.CallExpression:
+		// Evaluate quote calls without evaluating the quoted argument
+		if isSpecialForm(node, quoteName) {
+			return in.evalQuote(node, env)
+		}
+
 		// Evaluate the function
-		function := Evaluate(node.Function, env)
-		// Check if the evaluated value is an error
-		if isError(function) {
-			// Return the error
+		function := in.evalChain(node.Function, env)
+		// Check if the evaluated value is an error or a short-circuited chain
+		if isError(function) || function == shortCircuited {
+			// Return the error or the short-circuited chain
 			return function
 		}
 
 		// Evaluate the function arguments
-		args := evalExpressions(node.Arguments, env)
+		args := in.evalExpressions(node.Arguments, env)
 		// Check for errors
 		if len(args) == 1 && isError(args[0]) {
 			// Return the error
@@ -116,20 +229,26 @@ func Evaluate(node syntaxtree.Node, env *object.Environment) object.Object {
 		}
 
 		// Evaluate the function call
-		return applyFunction(function, args)
+		return traceCall(in.applyFunction(function, args), function, node)
 
 	// Identifier Expression Node
 	case *syntaxtree.IndexExpression:
 		// Evaluate the left expression
-		left := Evaluate(node.Left, env)
-		// Check if evaluated value is an error
-		if isError(left) {
-			// Return the error
+		left := in.evalChain(node.Left, env)
+		// Check if evaluated value is an error or a short-circuited chain
+		if isError(left) || left == shortCircuited {
+			// Return the error or the short-circuited chain
 			return left
 		}
 
+		// Check if the index is optional and the value is null
+		if node.Optional && left == NULL {
+			// Short-circuit the chain without evaluating the index
+			return shortCircuited
+		}
+
 		// Evaluate the index expression
-		index := Evaluate(node.Index, env)
+		index := in.eval(node.Index, env)
 		// Check if evaluated value is an error
 		if isError(index) {
 			// Return the error
@@ -137,35 +256,74 @@ func Evaluate(node syntaxtree.Node, env *object.Environment) object.Object {
 		}
 
 		// Evaluate the index expression
-		return evalIndexExpression(left, index)
+		return locateError(in.evalIndexExpression(left, index), node.Token)
+
+	// Member Expression Node
+	case *syntaxtree.MemberExpression:
+		// Evaluate the accessed object
+		obj := in.evalChain(node.Object, env)
+		// Check if evaluated value is an error or a short-circuited chain
+		if isError(obj) || obj == shortCircuited {
+			// Return the error or the short-circuited chain
+			return obj
+		}
+
+		// Check if the access is optional and the object is null
+		if node.Optional && obj == NULL {
+			// Short-circuit the chain
+			return shortCircuited
+		}
+
+		// Evaluate the member access
+		return locateError(in.evalMemberExpression(obj, node.Property), node.Property.Token)
+
+	// Slice Expression Node
+	case *syntaxtree.SliceExpression:
+		// Evaluate the slice expression
+		return locateError(in.evalSliceExpression(node, env), node.Token)
+
+	// Grouped Expression Node
+	case *syntaxtree.GroupedExpression:
+		// Evaluate the expression and end the optional chain in it
+		return in.eval(node.Expression, env)
 
 	// List Literal Node
 	case *syntaxtree.ListLiteral:
 		// Evaluate the list literal elements
-		elements := evalExpressions(node.Elements, env)
+		elements := in.evalExpressions(node.Elements, env)
 		// Check for errors
 		if len(elements) == 1 && isError(elements[0]) {
 			// Return the error
 			return elements[0]
 		}
 
-		// Return the List Object
-		return &object.List{Elements: elements}
+		// Return the List Object after accounting for its allocation
+		return locateError(in.allocate(&object.List{Elements: elements}), node.Token)
 
 	// Map Literal Node
 	case *syntaxtree.MapLiteral:
 		// Evaluate the map literal
-		return evalMapLiteral(node, env)
+		return locateError(in.evalMapLiteral(node, env), node.Token)
+
+	// Interpolated String Literal Node
+	case *syntaxtree.InterpolatedString:
+		// Evaluate the interpolated string
+		return locateError(in.evalInterpolatedString(node, env), node.Token)
 
 	// Function Literal Node
 	case *syntaxtree.FunctionLiteral:
 		// Return the Function Object
 		return &object.Function{Parameters: node.Parameters, Env: env, Body: node.Body}
 
+	// Macro Literal Node (macros are defined before the program is evaluated)
+	case *syntaxtree.MacroLiteral:
+		// Return Error
+		return locateError(object.NewErrorOfKind(object.MACRO_ERROR, "macros can only be defined by top-level let statements"), node.Token)
+
 	// Identifier Literal Node
 	case *syntaxtree.Identifier:
 		// Evaluate the identifier
-		return evalIdentifier(node, env)
+		return locateError(in.evalIdentifier(node, env), node.Token)
 
 	// Integer Literal Node
 	case *syntaxtree.IntegerLiteral:
@@ -177,10 +335,15 @@ func Evaluate(node syntaxtree.Node, env *object.Environment) object.Object {
 		// Return the native Boolean Object for the value
 		return getNativeBoolean(node.Value)
 
+	// Null Literal Node
+	case *syntaxtree.NullLiteral:
+		// Return the native Null Object
+		return NULL
+
 	// String Literal Node
 	case *syntaxtree.StringLiteral:
-		// Return the String Object
-		return &object.String{Value: node.Value}
+		// Return the String Object after accounting for its allocation
+		return locateError(in.allocate(&object.String{Value: node.Value}), node.Token)
 	}
 
 	// Return nil if not evaluated
@@ -236,26 +399,50 @@ func isError(obj object.Object) bool {
 	return false
 }
 
-// A function that applies a given function object on a slice of object arguments
-func applyFunction(fn object.Object, args []object.Object) object.Object {
+// A method of Interpreter that applies a given function object on a slice of object arguments
+func (in *Interpreter) applyFunction(fn object.Object, args []object.Object) object.Object {
 
 	switch fn := fn.(type) {
 
 	case *object.Function:
+		// Check if the evaluation has been cancelled
+		if err := in.state.ctx.Err(); err != nil {
+			// Return an Error
+			return object.NewErrorOfKind(object.CANCELLED_ERROR, "evaluation cancelled: %s", err)
+		}
+
+		// Check the number of arguments
+		if len(args) != len(fn.Parameters) {
+			// Return an Error that names the function if it is named
+			if fn.Name != "" {
+				return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments for %s. got=%d, want=%d", fn.Name, len(args), len(fn.Parameters))
+			}
+			return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=%d", len(args), len(fn.Parameters))
+		}
+
 		// Create the function's extended environment
 		extendedEnv := extendFunctionEnv(fn, args)
-		// Evaluate the function body
-		evaluated := Evaluate(fn.Body, extendedEnv)
+		// Evaluate the function body in the extended environment, which
+		// is the scope of both the parameters and the body of the function
+		evaluated := in.evalBlockStatement(fn.Body, extendedEnv)
 		// Return the unwrapped value
 		return unwrapReturnValue(evaluated)
 
 	case *object.Builtin:
 		// Call the built-in function with the args
-		return fn.Fn(args...)
+		return in.callBuiltin(fn, args)
+
+	case *object.Struct:
+		// Construct an instance of the struct with the args
+		return in.constructInstance(fn, args)
+
+	case *object.EnumVariant:
+		// Construct a value of the variant with the args
+		return in.constructEnumValue(fn, args)
 
 	default:
 		// Return an Error
-		return object.NewError("not a function: %s", fn.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "not a function: %s", fn.Type())
 	}
 }
 
@@ -284,3 +471,66 @@ func unwrapReturnValue(obj object.Object) object.Object {
 	// Return the object back
 	return obj
 }
+
+// A function that records the position of a token on an Error object if
+// the position of the error is not yet known and returns the object
+func locateError(obj object.Object, tok lexer.Token) object.Object {
+	// Check if the object is an Error without a position
+	if err, ok := obj.(*object.Error); ok && err.Line == 0 {
+		// Set the position of the error
+		err.Line, err.Column = tok.Line, tok.Column
+	}
+
+	// Return the object
+	return obj
+}
+
+// A function that records a call on the stack of an Error object returned by the call
+// and returns the object. Errors raised by the call itself (such as an argument count
+// mismatch or a failing built-in function) are located at the call site instead.
+func traceCall(obj object.Object, fn object.Object, call *syntaxtree.CallExpression) object.Object {
+	// Check if the object is an Error
+	err, ok := obj.(*object.Error)
+	if !ok {
+		return obj
+	}
+
+	// Locate errors that did not occur within the body of a Function at the call site
+	function, ok := fn.(*object.Function)
+	if !ok || err.Line == 0 {
+		return locateError(err, call.Token)
+	}
+
+	// Retrieve the name of the called function
+	name := function.Name
+	if name == "" {
+		name = functionName(call.Function)
+	}
+
+	// Add the call to the stack of the error
+	err.Stack = append(err.Stack, object.Frame{
+		Function: name,
+		Line:     call.Token.Line,
+		Column:   call.Token.Column,
+	})
+
+	// Return the error
+	return err
+}
+
+// A function that returns the name of an anonymous called function from the
+// expression of the call. Functions that are not called by name are anonymous.
+func functionName(callee syntaxtree.Expression) string {
+	// Check if the function is called by its identifier
+	if ident, ok := callee.(*syntaxtree.Identifier); ok {
+		return ident.Value
+	}
+
+	// Check if the function is called as a member (such as a method)
+	if member, ok := callee.(*syntaxtree.MemberExpression); ok {
+		return member.Property.Value
+	}
+
+	// Return the anonymous function name
+	return "<anonymous>"
+}
@@ -0,0 +1,70 @@
+package evaluator
+
+import (
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// A function that evaluates an enum declaration given an EnumStatement
+// syntax tree node. The Enum object is bound to the name of the declaration.
+func evalEnumStatement(node *syntaxtree.EnumStatement, env *object.Environment) object.Object {
+	// Create the Enum object
+	enum := &object.Enum{Name: node.Name.Value, Variants: make(map[string]*object.EnumVariant, len(node.Variants))}
+
+	// Create the variants of the enum
+	for _, variant := range node.Variants {
+		fields := []string{}
+		for _, field := range variant.Fields {
+			fields = append(fields, field.Value)
+		}
+
+		enum.Variants[variant.Name.Value] = &object.EnumVariant{Enum: enum, Name: variant.Name.Value, Fields: fields}
+	}
+
+	// Bind the enum to its name
+	if result := env.Set(node.Name.Value, enum); isError(result) {
+		return locateError(result, node.Name.Token)
+	}
+
+	return nil
+}
+
+// A function that retrieves a variant of an Enum given its name. Variants with a
+// payload are returned as constructors, variants without a payload as values.
+func enumMember(enum *object.Enum, name string) object.Object {
+	// Retrieve the variant with the name
+	variant, ok := enum.Variants[name]
+	if !ok {
+		return object.NewErrorOfKind(object.NAME_ERROR, "enum %s has no variant: %s", enum.Name, name)
+	}
+
+	// Return the value of a variant without a payload
+	if len(variant.Fields) == 0 {
+		return &object.EnumValue{Variant: variant}
+	}
+
+	return variant
+}
+
+// A function that retrieves a payload field of an EnumValue given its name
+func enumValueMember(value *object.EnumValue, name string) object.Object {
+	// Retrieve the payload field with the name
+	if field, ok := value.Field(name); ok {
+		return field
+	}
+
+	// Return Error
+	return object.NewErrorOfKind(object.NAME_ERROR, "%s has no field: %s", value.Variant.QualifiedName(), name)
+}
+
+// A method of Interpreter that constructs an EnumValue of a variant from the arguments of a
+// call to the variant. The arguments are the payload in the order of the variant's fields.
+func (in *Interpreter) constructEnumValue(variant *object.EnumVariant, args []object.Object) object.Object {
+	// Check the number of arguments
+	if len(args) != len(variant.Fields) {
+		return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments for %s. got=%d, want=%d", variant.QualifiedName(), len(args), len(variant.Fields))
+	}
+
+	// Return the EnumValue after accounting for its allocation
+	return in.allocate(&object.EnumValue{Variant: variant, Payload: append([]object.Object{}, args...)})
+}
@@ -1,6 +1,13 @@
 package evaluator
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/manishmeganathan/tunalang/lexer"
@@ -401,6 +408,14 @@ func TestListIndexExpressions(t *testing.T) {
 		},
 		{
 			"[1, 2, 3][-1]",
+			3,
+		},
+		{
+			"[1, 2, 3][-3]",
+			1,
+		},
+		{
+			"[1, 2, 3][-4]",
 			nil,
 		},
 	}
@@ -492,6 +507,21 @@ func testEval(input string) object.Object {
 	return Evaluate(program, env)
 }
 
+// A function that returns the text of an evaluated object for comparisons in tests, which is the
+// message of an Error, the value of a String and the inspected value of any other object
+func inspectResult(obj object.Object) string {
+	switch obj := obj.(type) {
+	case nil:
+		return ""
+	case *object.Error:
+		return obj.Message
+	case *object.String:
+		return obj.Value
+	default:
+		return obj.Inspect()
+	}
+}
+
 func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
 	result, ok := obj.(*object.Integer)
 
@@ -535,3 +565,1738 @@ func testNullObject(t *testing.T, obj object.Object) bool {
 
 	return true
 }
+
+func TestAllocationLimit(t *testing.T) {
+	tests := []struct {
+		input    string
+		limit    int64
+		expected interface{}
+	}{
+		{`len(push([1, 2], 3))`, 0, 3},
+		{`len(push([1, 2], 3))`, 1024, 3},
+		{`"a" + "b"`, 16, "out of memory: allocation limit of 16 bytes exceeded"},
+		{`let grow = fn(xs, n) { if (n > 0) { grow(push(xs, n), n - 1) } else { len(xs) } }; grow([], 1000)`, 0, 1000},
+		{`let grow = fn(xs, n) { if (n > 0) { grow(push(xs, n), n - 1) } else { len(xs) } }; grow([], 1000)`, 65536, "out of memory: allocation limit of 65536 bytes exceeded"},
+		{`let double = fn(s, n) { if (n > 0) { double(s + s, n - 1) } else { len(s) } }; double("ab", 30)`, 1 << 20, "out of memory: allocation limit of 1048576 bytes exceeded"},
+		{`{"a": [1, 2, 3], "b": "c"}`, 64, "out of memory: allocation limit of 64 bytes exceeded"},
+	}
+
+	defer SetAllocationLimit(0)
+
+	for _, tt := range tests {
+		SetAllocationLimit(tt.limit)
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestConcatenationReservation(t *testing.T) {
+	tests := []string{
+		`let double = fn(s) { double(s + s) }; double("abcdefgh")`,
+		`let double = fn(l) { double(l + l) }; double([1, 2, 3, 4])`,
+	}
+
+	for _, input := range tests {
+		interp := NewInterpreter(WithLimits(Limits{MaxAllocation: 1 << 16}))
+		errObj, ok := testEvalWith(interp, input).(*object.Error)
+		if !ok || errObj.Kind != object.MEMORY_ERROR {
+			t.Errorf("no out-of-memory error for %q. got=%+v", input, errObj)
+			continue
+		}
+
+		// The concatenation that would exceed the limit is not created
+		if allocated := interp.AllocatedBytes(); allocated > 1<<16 {
+			t.Errorf("allocation limit exceeded for %q. allocated=%d", input, allocated)
+		}
+	}
+}
+
+func TestSizeOf(t *testing.T) {
+	tests := []struct {
+		obj      object.Object
+		expected int64
+	}{
+		{&object.String{Value: "hello"}, 21},
+		{&object.List{Elements: []object.Object{TRUE, FALSE}}, 48},
+		{&object.Integer{Value: 5}, 0},
+		{NULL, 0},
+	}
+
+	for _, tt := range tests {
+		if size := SizeOf(tt.obj); size != tt.expected {
+			t.Errorf("wrong size for %s. expected=%d, got=%d", tt.obj.Inspect(), tt.expected, size)
+		}
+	}
+}
+
+func TestInterpreterIsolation(t *testing.T) {
+	var outA, outB bytes.Buffer
+
+	interpA := NewInterpreter(WithStdout(&outA))
+	interpB := NewInterpreter(
+		WithStdout(&outB),
+		WithBuiltin("len", func(args ...object.Object) object.Object {
+			return &object.Integer{Value: 42}
+		}),
+	)
+
+	testEvalWith(interpA, `puts("alpha")`)
+	testEvalWith(interpB, `puts("beta", 1)`)
+
+	if outA.String() != "alpha\n" {
+		t.Errorf("wrong output for interpreter A. got=%q", outA.String())
+	}
+
+	if outB.String() != "beta\n1\n" {
+		t.Errorf("wrong output for interpreter B. got=%q", outB.String())
+	}
+
+	testIntegerObject(t, testEvalWith(interpA, `len("abc")`), 3)
+	testIntegerObject(t, testEvalWith(interpB, `len("abc")`), 42)
+}
+
+func TestInterpreterStreams(t *testing.T) {
+	var stderr bytes.Buffer
+	interp := NewInterpreter(
+		WithStdin(strings.NewReader("first line\nsecond line")),
+		WithStderr(&stderr),
+	)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`gets()`, "first line"},
+		{`gets()`, "second line"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvalWith(interp, tt.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Fatalf("object is not String. got=%T (%+v)", evaluated, evaluated)
+		}
+
+		if str.Value != tt.expected {
+			t.Errorf("String has wrong value. expected=%q, got=%q", tt.expected, str.Value)
+		}
+	}
+
+	testNullObject(t, testEvalWith(interp, `gets()`))
+
+	testEvalWith(interp, `eputs("oops")`)
+	if stderr.String() != "oops\n" {
+		t.Errorf("wrong output for stderr. got=%q", stderr.String())
+	}
+}
+
+func TestInterpreterLimits(t *testing.T) {
+	interp := NewInterpreter(WithLimits(Limits{MaxAllocation: 100}))
+	evaluated := testEvalWith(interp, `"abcdefghijklmnopqrstuvwxyz" + "abcdefghijklmnopqrstuvwxyz"`)
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if errObj.Message != "out of memory: allocation limit of 100 bytes exceeded" {
+		t.Errorf("wrong error message. got=%q", errObj.Message)
+	}
+
+	if interp.AllocatedBytes() == 0 {
+		t.Errorf("allocations were not accounted on the interpreter")
+	}
+
+	// Each top-level evaluation has its own allocation budget
+	env := object.NewEnvironment()
+	for i := 0; i < 10; i++ {
+		program := parser.NewParser(lexer.NewLexer(`let s = "abcdefghijklmnopqrstuvwxyz"; s`)).ParseProgram()
+		if errObj, ok := interp.Evaluate(program, env).(*object.Error); ok {
+			t.Fatalf("evaluation %d exceeded the limit: %s", i, errObj.Message)
+		}
+	}
+
+	// The limits are applied when an evaluation starts
+	interp.Limits.MaxAllocation = 0
+	evaluated = testEvalWith(interp, `"abcdefghijklmnopqrstuvwxyz" + "abcdefghijklmnopqrstuvwxyz"`)
+	if errObj, ok := evaluated.(*object.Error); ok {
+		t.Errorf("limit was not updated. got=%s", errObj.Message)
+	}
+}
+
+func testEvalWith(interp *Interpreter, input string) object.Object {
+	l := lexer.NewLexer(input)
+	p := parser.NewParser(l)
+	program := p.ParseProgram()
+	env := object.NewEnvironment()
+
+	return interp.Evaluate(program, env)
+}
+
+func TestInterpreterCall(t *testing.T) {
+	interp := NewInterpreter()
+	env := object.NewEnvironment()
+
+	program := parser.NewParser(lexer.NewLexer(`
+	let offset = 10;
+	let add = fn(x, y) { return x + y + offset; };
+	let fail = fn() { missing };
+	`)).ParseProgram()
+	interp.Evaluate(program, env)
+
+	add, _ := env.Get("add")
+	result, err := interp.Call(context.Background(), add, &object.Integer{Value: 1}, &object.Integer{Value: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testIntegerObject(t, result, 13)
+
+	builtin, _ := interp.Builtin("len")
+	result, err = interp.Call(context.Background(), builtin, &object.String{Value: "tuna"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testIntegerObject(t, result, 4)
+
+	errorTests := []struct {
+		fn       object.Object
+		args     []object.Object
+		expected string
+	}{
+		{add, []object.Object{&object.Integer{Value: 1}}, "wrong number of arguments. got=1, want=2"},
+		{&object.Integer{Value: 1}, nil, "not a function: INTEGER"},
+	}
+
+	fail, _ := env.Get("fail")
+	errorTests = append(errorTests, struct {
+		fn       object.Object
+		args     []object.Object
+		expected string
+	}{fail, nil, "identifier not found: missing"})
+
+	for _, tt := range errorTests {
+		_, err := interp.Call(context.Background(), tt.fn, tt.args...)
+		errObj, ok := err.(*object.Error)
+		if !ok {
+			t.Errorf("error is not Error. got=%T (%+v)", err, err)
+			continue
+		}
+
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+	}
+}
+
+func TestInterpreterCallCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	interp := NewInterpreter(WithBuiltin("stop", func(args ...object.Object) object.Object {
+		cancel()
+		return NULL
+	}))
+
+	env := object.NewEnvironment()
+	interp.Evaluate(parser.NewParser(lexer.NewLexer(`
+	let loop = fn(n) { if (n == 5) { stop() }; loop(n + 1) };
+	`)).ParseProgram(), env)
+
+	loop, _ := env.Get("loop")
+	_, err := interp.Call(ctx, loop, &object.Integer{Value: 0})
+	if err != context.Canceled {
+		t.Errorf("wrong error. expected=%v, got=%v", context.Canceled, err)
+	}
+
+	_, err = interp.Call(ctx, loop, &object.Integer{Value: 0})
+	if err != context.Canceled {
+		t.Errorf("wrong error for cancelled context. expected=%v, got=%v", context.Canceled, err)
+	}
+}
+
+func TestInterpreterCallConcurrent(t *testing.T) {
+	var interp *Interpreter
+	interp = NewInterpreter(WithBuiltin("apply", func(args ...object.Object) object.Object {
+		result, err := interp.Call(context.Background(), args[0], args[1])
+		if err != nil {
+			return object.NewError("%s", err)
+		}
+		return result
+	}))
+
+	env := object.NewEnvironment()
+	interp.Evaluate(parser.NewParser(lexer.NewLexer(`
+	let square = fn(x) { x * x };
+	let twice = fn(x) { apply(square, x) + apply(square, x) };
+	`)).ParseProgram(), env)
+
+	twice, _ := env.Get("twice")
+
+	var wg sync.WaitGroup
+	results := make([]object.Object, 20)
+	for idx := range results {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			results[idx], _ = interp.Call(context.Background(), twice, &object.Integer{Value: int64(idx)})
+		}(idx)
+	}
+	wg.Wait()
+
+	for idx, result := range results {
+		testIntegerObject(t, result, int64(2*idx*idx))
+	}
+}
+
+func TestInterpreterRegisterConcurrent(t *testing.T) {
+	interp := NewInterpreter()
+	env := object.NewEnvironment()
+	interp.Evaluate(parser.NewParser(lexer.NewLexer(`let f = fn(n) { if (n == 0) { len("abc") } else { f(n - 1) } }`)).ParseProgram(), env)
+	f, _ := env.Get("f")
+
+	// Register builtins and methods while a function is called (run with -race)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		result, _ := interp.Call(context.Background(), f, &object.Integer{Value: 500})
+		testIntegerObject(t, result, 3)
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			interp.RegisterBuiltin(fmt.Sprintf("extra%d", i), func(args ...object.Object) object.Object { return NULL })
+			interp.RegisterMethod(object.STRING_OBJ, fmt.Sprintf("extra%d", i), func(receiver object.Object, args ...object.Object) object.Object { return NULL })
+			interp.Builtin("len")
+		}
+	}()
+	wg.Wait()
+
+	if _, ok := interp.Builtin("extra99"); !ok {
+		t.Errorf("builtin was not registered")
+	}
+}
+
+func TestErrorStackTraces(t *testing.T) {
+	input := `let inner = fn(x) {
+	x + missing
+};
+let outer = fn(x) {
+	let y = x * 2;
+	inner(y)
+};
+outer(1);`
+
+	evaluated := testEval(input)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if errObj.Line != 2 || errObj.Column != 6 {
+		t.Errorf("wrong error position. expected=2:6, got=%d:%d", errObj.Line, errObj.Column)
+	}
+
+	expected := []object.Frame{
+		{Function: "inner", Line: 6, Column: 7},
+		{Function: "outer", Line: 8, Column: 6},
+	}
+
+	if len(errObj.Stack) != len(expected) {
+		t.Fatalf("wrong stack length. expected=%d, got=%d", len(expected), len(errObj.Stack))
+	}
+
+	for idx, frame := range expected {
+		if errObj.Stack[idx] != frame {
+			t.Errorf("wrong frame %d. expected=%+v, got=%+v", idx, frame, errObj.Stack[idx])
+		}
+	}
+
+	traceback := `Traceback (most recent call last):
+  line 8, column 6, in <program>
+  line 6, column 7, in outer
+  line 2, column 6, in inner
+ERROR: identifier not found: missing`
+
+	if errObj.Traceback() != traceback {
+		t.Errorf("wrong traceback. expected=\n%s\ngot=\n%s", traceback, errObj.Traceback())
+	}
+}
+
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedLine   int
+		expectedColumn int
+		expectedStack  int
+	}{
+		{"5 + true;", 1, 3, 0},
+		{"let x = 1;\n-true", 2, 1, 0},
+		{"let f = fn(a) { a };\nf(1, 2)", 2, 2, 0},
+		{"len(1)", 1, 4, 0},
+		{"[1, 2][\"a\"]", 1, 7, 0},
+		{"let f = fn() { len(1) };\nf()", 1, 19, 1},
+		{"fn() { foo }()", 1, 8, 1},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Line != tt.expectedLine || errObj.Column != tt.expectedColumn {
+			t.Errorf("wrong error position for %q. expected=%d:%d, got=%d:%d", tt.input, tt.expectedLine, tt.expectedColumn, errObj.Line, errObj.Column)
+		}
+
+		if len(errObj.Stack) != tt.expectedStack {
+			t.Errorf("wrong stack length for %q. expected=%d, got=%d", tt.input, tt.expectedStack, len(errObj.Stack))
+		}
+	}
+}
+
+func TestTryExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`try { 1 } catch (e) { 2 }`, 1},
+		{`try { missing } catch (e) { 2 }`, 2},
+		{`try { throw 5; 1 } catch (e) { e.value * 2 }`, 10},
+		{`try { throw "oops" } catch (e) { e.value }`, "oops"},
+		{`try { throw "oops" } catch (e) { e.message }`, "oops"},
+		{`try { throw 1 } catch (e) { e.kind }`, "ThrownError"},
+		{`try { throw 1 } catch (e) { e.line }`, 1},
+		{`let f = fn() { throw 1 }; let g = fn() { f() }; try { g() } catch (e) { e.stack[0].function + " " + e.stack[1].function }`, "f g"},
+		{`try { missing } catch (e) { e.value }`, nil},
+		{`try { missing } catch (e) { e["message"] }`, "identifier not found: missing"},
+		{`try { missing } catch (e) { e["kind"] }`, "NameError"},
+		{`try { 1 + true } catch (e) { e["kind"] }`, "TypeError"},
+		{`try { len(1, 2) } catch (e) { e["kind"] }`, "ArgumentError"},
+		{`let f = fn() { {}[fn() {}] }; let g = fn() { f() }; try { g() } catch (e) { len(e["stack"]) }`, 2},
+		{`let f = fn() { missing }; try { f() } catch (e) { e["stack"][0]["function"] }`, "f"},
+		{`try { missing } catch (e) { e["line"] }`, 1},
+		{`try { 1 } catch (e) { 2 } finally { 3 }`, 1},
+		{`try { missing } finally { 3 }`, "identifier not found: missing"},
+		{`try { throw 1 } catch (e) { throw e.value + 1 }`, "2"},
+		{`try { try { throw 1 } catch (e) { throw e.value + 1 } } catch (e) { e.value }`, 2},
+		{`try { try { throw [1, 2] } catch (e) { throw e } } catch (e) { len(e.value) }`, 2},
+		{`try { throw {"message": "m", "kind": "k", "value": 1} } catch (e) { e.value.value }`, 1},
+		{`try { try { missing } catch (e) { throw e } } catch (e) { e["kind"] }`, "NameError"},
+		{`let f = fn() { try { return 1; } finally { 0 }; 2 }; f()`, 1},
+		{`let f = fn() { try { return 1; } finally { return 3; } }; f()`, 3},
+		{`let f = fn() { try { 1 } catch (e) { 2 } finally { missing } }; f()`, "identifier not found: missing"},
+		{`try { } catch (e) { 1 }`, nil},
+		{`throw {"message": "custom failure", "kind": "ValueError"}`, "custom failure"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case nil:
+			testNullObject(t, evaluated)
+		case string:
+			switch obj := evaluated.(type) {
+			case *object.String:
+				if obj.Value != expected {
+					t.Errorf("String has wrong value. expected=%q, got=%q", expected, obj.Value)
+				}
+			case *object.Error:
+				if obj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, obj.Message)
+				}
+			default:
+				t.Errorf("object is not String or Error. got=%T (%+v)", evaluated, evaluated)
+			}
+		}
+	}
+}
+
+func TestThrownErrors(t *testing.T) {
+	evaluated := testEval(`let f = fn(x) { throw {"message": "bad value", "kind": "ValueError", "value": x} };
+f(5)`)
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if errObj.Kind != "ValueError" || errObj.Message != "bad value" {
+		t.Errorf("wrong error. got kind=%q, message=%q", errObj.Kind, errObj.Message)
+	}
+
+	if _, ok := errObj.Value.(*object.Map); !ok {
+		t.Errorf("thrown value is not Map. got=%T", errObj.Value)
+	}
+
+	if len(errObj.Stack) != 1 || errObj.Line != 1 || errObj.Column != 17 {
+		t.Errorf("wrong error location. got=%d:%d with %d frames", errObj.Line, errObj.Column, len(errObj.Stack))
+	}
+
+	evaluated = testEval(`throw [1, 2]`)
+	errObj, ok = evaluated.(*object.Error)
+	if !ok || errObj.Kind != object.THROWN_ERROR || errObj.Message != "[1, 2]" {
+		t.Errorf("wrong error for thrown list. got=%+v", evaluated)
+	}
+}
+
+func TestTryMemoryErrors(t *testing.T) {
+	interp := NewInterpreter(WithLimits(Limits{MaxAllocation: 1000}))
+
+	tests := []string{
+		`try { "a" * 100000 } catch (e) { e.kind }`,
+		`let grow = fn(s) { grow(s + s) }; try { grow("ab") } catch (e) { "caught" }`,
+		`try { try { "a" * 100000 } finally { 1 } } catch (e) { "caught" }`,
+	}
+
+	for _, input := range tests {
+		errObj, ok := testEvalWith(interp, input).(*object.Error)
+		if !ok || errObj.Kind != object.MEMORY_ERROR {
+			t.Errorf("out-of-memory error was caught for %q. got=%s", input, inspectResult(testEvalWith(interp, input)))
+		}
+	}
+
+	// Thrown values with the kind of an out-of-memory error can be caught
+	evaluated := testEvalWith(interp, `try { throw {"message": "m", "kind": "MemoryError"} } catch (e) { e.kind }`)
+	if actual := inspectResult(evaluated); actual != "MemoryError" {
+		t.Errorf("thrown value was not caught. got=%q", actual)
+	}
+}
+
+func TestTryCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	interp := NewInterpreter(WithBuiltin("stop", func(args ...object.Object) object.Object {
+		cancel()
+		return NULL
+	}))
+
+	env := object.NewEnvironment()
+	interp.Evaluate(parser.NewParser(lexer.NewLexer(`
+	let work = fn() { stop(); fn() { 1 }() };
+	let guarded = fn() { try { work() } catch (e) { "swallowed" } };
+	`)).ParseProgram(), env)
+
+	guarded, _ := env.Get("guarded")
+	if _, err := interp.Call(ctx, guarded); err != context.Canceled {
+		t.Errorf("cancellation was caught. got=%v", err)
+	}
+}
+
+func TestStructuralEquality(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`"a" == "a"`, true},
+		{`"a" == "b"`, false},
+		{`"a" != "b"`, true},
+		{`"a" != "a"`, false},
+		{`"a" + "b" == "ab"`, true},
+		{`[1, 2] == [1, 2]`, true},
+		{`[1, 2] == [2, 1]`, false},
+		{`[1, 2] == [1, 2, 3]`, false},
+		{`[1, 2] != [1, 2]`, false},
+		{`[1, [2, "x"]] == [1, [2, "x"]]`, true},
+		{`[] == []`, true},
+		{`{"a": 1, "b": [2]} == {"b": [2], "a": 1}`, true},
+		{`{"a": 1} == {"a": 2}`, false},
+		{`{"a": 1} == {"b": 1}`, false},
+		{`{"a": 1} != {"a": 1, "b": 2}`, true},
+		{`1 == "1"`, false},
+		{`"1" != 1`, true},
+		{`[1] == 1`, false},
+		{`let f = fn() {}; f == f`, true},
+		{`fn() {} == fn() {}`, false},
+		{`if (false) { 1 } == if (false) { 2 }`, true},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		testBooleanObject(t, evaluated, tt.expected)
+	}
+}
+
+func TestOrderingOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`1 <= 1`, true},
+		{`1 >= 2`, false},
+		{`2 >= 1`, true},
+		{`"apple" < "banana"`, true},
+		{`"apple" > "banana"`, false},
+		{`"Zebra" < "apple"`, true},
+		{`"ab" < "abc"`, true},
+		{`"abc" <= "abc"`, true},
+		{`"abc" >= "abd"`, false},
+		{`"é" > "z"`, true},
+		{`[1, 2] < [1, 3]`, true},
+		{`[1, 2] < [1, 2, 0]`, true},
+		{`[2] > [1, 9]`, true},
+		{`[1, 2] <= [1, 2]`, true},
+		{`[] < [1]`, true},
+		{`[["a"], 1] < [["b"], 0]`, true},
+		{`1 < "2"`, "cannot compare INTEGER < STRING"},
+		{`"a" >= [1]`, "cannot compare STRING >= LIST"},
+		{`{"a": 1} < {"a": 2}`, "cannot compare MAP < MAP"},
+		{`true > false`, "cannot compare BOOLEAN > BOOLEAN"},
+		{`[1, "a"] < [1, 2]`, "cannot compare LIST < LIST"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned for %q. got=%T(%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+
+			if errObj.Kind != object.TYPE_ERROR {
+				t.Errorf("wrong error kind. expected=%q, got=%q", object.TYPE_ERROR, errObj.Kind)
+			}
+		}
+	}
+}
+
+func TestListAndStringOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`[1] + [2]`, "[1, 2]"},
+		{`[] + []`, "[]"},
+		{`[1, 2] + [] + [3]`, "[1, 2, 3]"},
+		{`[1, 2] * 2`, "[1, 2, 1, 2]"},
+		{`3 * [0]`, "[0, 0, 0]"},
+		{`[1] * 0`, "[]"},
+		{`[1] * -2`, "[]"},
+		{`"ab" * 3`, "ababab"},
+		{`2 * "-"`, "--"},
+		{`"ab" * 0`, ""},
+		{`1 in [1, 2]`, true},
+		{`3 in [1, 2]`, false},
+		{`[1] in [[1], [2]]`, true},
+		{`"a" in ["a", "b"]`, true},
+		{`"1" in [1]`, false},
+		{`"a" in {"a": 1}`, true},
+		{`"b" in {"a": 1}`, false},
+		{`1 in {1: "a"}`, true},
+		{`"ell" in "hello"`, true},
+		{`"" in "hello"`, true},
+		{`"xyz" in "hello"`, false},
+		{`1 + 1 in [2]`, true},
+		{`!(3 in [1])`, true},
+		{`[1] + 1`, "type mismatch: LIST + INTEGER"},
+		{`[1] - [1]`, "unsupported operator: LIST - LIST"},
+		{`[1] * [1]`, "unsupported operator: LIST * LIST"},
+		{`true * 2`, "unsupported operator: BOOLEAN * INTEGER"},
+		{`1 in 1`, "unsupported operator: INTEGER in INTEGER"},
+		{`1 in "1"`, "unsupported operator: INTEGER in STRING"},
+		{`[1] in {"a": 1}`, "unusable as hash key: LIST"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			if actual := inspectResult(evaluated); actual != expected {
+				t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, expected, actual)
+			}
+		}
+	}
+}
+
+func TestRepetitionAllocationLimit(t *testing.T) {
+	interp := NewInterpreter(WithLimits(Limits{MaxAllocation: 1024}))
+
+	tests := []string{
+		`"ab" * 100000000`,
+		`[1, 2] * 100000000`,
+		`"ab" * 9223372036854775807`,
+	}
+
+	for _, input := range tests {
+		errObj, ok := testEvalWith(interp, input).(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q", input)
+			continue
+		}
+
+		if errObj.Kind != object.MEMORY_ERROR {
+			t.Errorf("wrong error kind for %q. expected=%q, got=%q", input, object.MEMORY_ERROR, errObj.Kind)
+		}
+	}
+}
+
+func TestRepetitionWithoutLimit(t *testing.T) {
+	interp := NewInterpreter()
+
+	tests := []string{
+		`[1] * 100000000000000000`,
+		`"a" * 1000000000000000000`,
+		`3 * [1, 2, 3] * 1000000000`,
+		`"ab" * 9223372036854775807`,
+	}
+
+	for _, input := range tests {
+		errObj, ok := testEvalWith(interp, input).(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q", input)
+			continue
+		}
+
+		if errObj.Message != "out of memory: repeated value is too large" {
+			t.Errorf("wrong error message for %q. got=%q", input, errObj.Message)
+		}
+	}
+
+	testIntegerObject(t, testEvalWith(interp, `len("ab" * 1000)`), 2000)
+}
+
+func TestStringIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"hello"[0]`, "h"},
+		{`"hello"[4]`, "o"},
+		{`"hello"[-1]`, "o"},
+		{`"hello"[-5]`, "h"},
+		{`"héllo"[1]`, "é"},
+		{`"日本語"[2]`, "語"},
+		{`"日本語"[-3]`, "日"},
+		{`"hello"[5]`, nil},
+		{`"hello"[-6]`, nil},
+		{`""[0]`, nil},
+		{`let s = "héllo"; s[len(s) - 1]`, "o"},
+		{`let s = "日本語"; s[len(s)]`, nil},
+		{`let s = "héllo"; s[len(s) - 4:len(s)]`, "éllo"},
+		{`let s = "日本語"; s[:len(s) - 1]`, "日本"},
+		{`str(len("héllo")) + str(len("日本語")) + str("日本語".len())`, "533"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		expected, ok := tt.expected.(string)
+		if !ok {
+			testNullObject(t, evaluated)
+			continue
+		}
+
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("object is not String for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if str.Value != expected {
+			t.Errorf("wrong character for %q. expected=%q, got=%q", tt.input, expected, str.Value)
+		}
+	}
+}
+
+func TestStrictModeIndexErrors(t *testing.T) {
+	interp := NewInterpreter(WithStrictMode(true))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`[1, 2, 3][3]`, "index out of range: 3 (length 3)"},
+		{`[1, 2, 3][-4]`, "index out of range: -4 (length 3)"},
+		{`[][0]`, "index out of range: 0 (length 0)"},
+		{`"日本語"[3]`, "index out of range: 3 (length 3)"},
+	}
+
+	for _, tt := range tests {
+		errObj, ok := testEvalWith(interp, tt.input).(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q", tt.input)
+			continue
+		}
+
+		if errObj.Kind != object.INDEX_ERROR {
+			t.Errorf("wrong error kind. expected=%q, got=%q", object.INDEX_ERROR, errObj.Kind)
+		}
+
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+	}
+
+	// Indices within range are unaffected by strict mode
+	testIntegerObject(t, testEvalWith(interp, `[1, 2, 3][-1]`), 3)
+}
+
+func TestSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`[1, 2, 3, 4, 5][1:3]`, "[2, 3]"},
+		{`[1, 2, 3, 4, 5][:2]`, "[1, 2]"},
+		{`[1, 2, 3, 4, 5][3:]`, "[4, 5]"},
+		{`[1, 2, 3, 4, 5][:]`, "[1, 2, 3, 4, 5]"},
+		{`[1, 2, 3, 4, 5][-2:]`, "[4, 5]"},
+		{`[1, 2, 3, 4, 5][:-2]`, "[1, 2, 3]"},
+		{`[1, 2, 3, 4, 5][::2]`, "[1, 3, 5]"},
+		{`[1, 2, 3, 4, 5][1::2]`, "[2, 4]"},
+		{`[1, 2, 3, 4, 5][::-1]`, "[5, 4, 3, 2, 1]"},
+		{`[1, 2, 3, 4, 5][3:0:-1]`, "[4, 3, 2]"},
+		{`[1, 2, 3, 4, 5][-1:-3:-1]`, "[5, 4]"},
+		{`[1, 2, 3, 4, 5][::-2]`, "[5, 3, 1]"},
+		{`[1, 2, 3, 4, 5][10:]`, "[]"},
+		{`[1, 2, 3, 4, 5][-10:2]`, "[1, 2]"},
+		{`[1, 2, 3, 4, 5][3:1]`, "[]"},
+		{`[1, 2, 3, 4, 5][0:100:100]`, "[1]"},
+		{`[][:]`, "[]"},
+		{`let xs = [1, 2, 3]; let ys = xs[:]; xs == ys`, "true"},
+		{`"hello"[1:4]`, "ell"},
+		{`"hello"[::-1]`, "olleh"},
+		{`"日本語です"[1:3]`, "本語"},
+		{`"日本語"[-1:]`, "語"},
+		{`"hello"[10:20]`, ""},
+		{`[1, 2][::0]`, "slice step cannot be zero"},
+		{`[1, 2]["a":]`, "slice indices must be integers. got=STRING"},
+		{`{"a": 1}[0:1]`, "slice operator not supported: MAP"},
+		{`5[0:1]`, "slice operator not supported: INTEGER"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+}
+
+func TestBlockScoping(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		// Bindings in a block do not leak into the enclosing scope
+		{`let x = 1; if (true) { let x = 2; }; x`, 1},
+		{`let x = 1; if (false) { 0 } else { let x = 3; }; x`, 1},
+		{`if (true) { let y = 2; }; y`, "identifier not found: y"},
+		{`let x = 1; try { let x = 2; } finally { let x = 3; }; x`, 1},
+		{`try { throw 1 } catch (e) { let x = e; }; x`, "identifier not found: x"},
+		{`try { throw 1 } catch (e) { 0 }; e`, "identifier not found: e"},
+
+		// Shadowing is visible within the block and its nested blocks
+		{`let x = 1; if (true) { let x = 2; x }`, 2},
+		{`let x = 1; if (true) { let x = x + 1; if (true) { x * 10 } }`, 20},
+		{`let x = 1; if (true) { let x = 2; if (true) { let x = 3; }; x }`, 2},
+		{`let x = 5; if (true) { x }`, 5},
+
+		// Function bodies share the scope of their parameters
+		{`let f = fn(x) { let x = x * 2; x }; f(4)`, 8},
+		{`let x = 1; let f = fn() { let x = 2; x }; f() + x`, 3},
+
+		// Closures capture the scope of the block they are created in
+		{`let f = if (true) { let y = 5; fn() { y } }; f()`, 5},
+		{`let y = 1; let f = if (true) { let y = 2; fn() { y } }; f() + y`, 3},
+		{`let make = fn(n) { if (n > 0) { let k = n * 2; fn() { k + n } } }; make(3)()`, 9},
+
+		// Closures capture environments by reference, not values
+		{`let x = 1; let f = fn() { x }; let x = 2; f()`, 2},
+		{`let f = if (true) { let v = 1; let g = fn() { v }; let v = 10; g }; f()`, 10},
+		{`let x = 1; let f = fn() { x }; if (true) { let x = 2; f() }`, 1},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned for %q. got=%T(%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestConstBindings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`const x = 5; x`, 5},
+		{`const x = 5; const y = x * 2; y`, 10},
+		{`const x = 5; let x = 6; x`, "cannot reassign constant: x"},
+		{`const x = 5; const x = 6; x`, "cannot reassign constant: x"},
+		{`let x = 5; const x = 6; x`, 6},
+		{`let x = 5; const x = 6; let x = 7;`, "cannot reassign constant: x"},
+		{`const x = 5; if (true) { let x = 6; x }`, 6},
+		{`const x = 5; if (true) { const x = 6; x }`, 6},
+		{`const x = 5; if (true) { let x = 6; }; x`, 5},
+		{`const x = 5; let f = fn(x) { x }; f(7)`, 7},
+		{`const x = 5; let f = fn() { let x = 8; x }; f()`, 8},
+		{`let f = fn() { const v = 1; let v = 2; v }; f()`, "cannot reassign constant: v"},
+		{`const len = 1; len`, 1},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned for %q. got=%T(%+v)", tt.input, evaluated, evaluated)
+				continue
+			}
+
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+
+			if errObj.Kind != object.ASSIGNMENT_ERROR {
+				t.Errorf("wrong error kind. expected=%q, got=%q", object.ASSIGNMENT_ERROR, errObj.Kind)
+			}
+		}
+	}
+}
+
+func TestStrictModeDiagnostics(t *testing.T) {
+	tests := []struct {
+		input    string
+		kind     string
+		expected string
+	}{
+		{`"use strict"; let x = 1; let x = "a";`, object.DECLARATION_ERROR, "identifier already declared in this scope: x"},
+		{`"use strict"; const x = 1; let x = 2;`, object.DECLARATION_ERROR, "identifier already declared in this scope: x"},
+		{`"use strict"; let f = fn(a, a) { a };`, object.DECLARATION_ERROR, "identifier already declared in this scope: a"},
+		{`"use strict"; let f = fn(a) { let a = 1; a };`, object.DECLARATION_ERROR, "identifier already declared in this scope: a"},
+		{`"use strict"; if (true) { let y = 1; let y = 2; }`, object.DECLARATION_ERROR, "identifier already declared in this scope: y"},
+		{`"use strict"; try { 1 } catch (e) { let e = 2; }`, object.DECLARATION_ERROR, "identifier already declared in this scope: e"},
+		{`"use strict"; x; let x = 1;`, object.NAME_ERROR, "identifier not declared: x"},
+		{`"use strict"; let x = x + 1;`, object.NAME_ERROR, "identifier not declared: x"},
+		{`"use strict"; if (true) { let y = 1; }; y`, object.NAME_ERROR, "identifier not declared: y"},
+		{`"use strict"; let f = fn() { missing() };`, object.NAME_ERROR, "identifier not declared: missing"},
+		{`"use strict"; puts("never printed"); undefined`, object.NAME_ERROR, "identifier not declared: undefined"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T(%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Kind != tt.kind {
+			t.Errorf("wrong error kind for %q. expected=%q, got=%q", tt.input, tt.kind, errObj.Kind)
+		}
+
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+
+		if errObj.Line == 0 {
+			t.Errorf("error for %q has no position", tt.input)
+		}
+	}
+}
+
+func TestStrictModeAcceptedPrograms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		// Shadowing in an inner scope is not a redeclaration
+		{`"use strict"; let x = 1; if (true) { let x = 2; x }`, 2},
+		{`"use strict"; let x = 1; let f = fn(x) { x * 3 }; f(x)`, 3},
+		// Functions may refer to names declared after them
+		{`"use strict"; let f = fn() { g() }; let g = fn() { 4 }; f()`, 4},
+		{`"use strict"; let fact = fn(n) { if (n < 2) { 1 } else { n * fact(n - 1) } }; fact(3)`, 6},
+		// Built-in functions and caught values are declared
+		{`"use strict"; len([1]) + try { throw 1 } catch (e) { e.value }`, 2},
+		// A directive that is not the first statement has no effect
+		{`let x = 1; "use strict"; let x = 5; x`, 5},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+
+	// Without strict mode, redeclaration is allowed
+	testIntegerObject(t, testEval(`let x = 1; let x = 2; x`), 2)
+}
+
+func TestStrictModeOption(t *testing.T) {
+	interp := NewInterpreter(WithStrictMode(true))
+	env := object.NewEnvironment()
+
+	// Evaluate programs in the same environment (such as the lines of a REPL)
+	evaluate := func(input string) object.Object {
+		program := parser.NewParser(lexer.NewLexer(input)).ParseProgram()
+		return interp.Evaluate(program, env)
+	}
+
+	testIntegerObject(t, evaluate(`let x = 1; x`), 1)
+
+	// A binding in the environment may be declared again by a later program
+	testIntegerObject(t, evaluate(`let x = 2; x`), 2)
+
+	errObj, ok := evaluate(`let z = 1; let z = 2;`).(*object.Error)
+	if !ok || errObj.Kind != object.DECLARATION_ERROR {
+		t.Fatalf("redeclaration within a program was not reported. got=%+v", errObj)
+	}
+
+	errObj, ok = evaluate(`y`).(*object.Error)
+	if !ok || errObj.Message != "identifier not declared: y" {
+		t.Fatalf("undeclared read was not reported. got=%+v", errObj)
+	}
+
+	testIntegerObject(t, evaluate(`x + 1`), 3)
+
+	// A strict directive also enables strict index errors for its program
+	errObj, ok = testEval(`"use strict"; [1][5]`).(*object.Error)
+	if !ok || errObj.Kind != object.INDEX_ERROR {
+		t.Fatalf("strict directive did not enable index errors. got=%+v", errObj)
+	}
+	testNullObject(t, testEval(`[1][5]`))
+}
+
+func writeModules(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	for name, source := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestModuleImports(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"math.tuna": `
+			export const two = 2;
+			export let double = fn(x) { x * two };
+			let hidden = 99;
+			export const [one, three] = [1, 3];
+			export fn sq(x) { x * x }
+			export struct Point { x, y }
+			export enum Sign { Positive, Negative }
+			puts("loaded math");
+		`,
+		"lib/strings.tuna": `
+			import "helpers" as helpers;
+			export let shout = fn(s) { s + helpers.bang };
+		`,
+		"lib/helpers.tuna": `export let bang = "!";`,
+	})
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`import "math" as m; m.double(21)`, 42},
+		{`import "math.tuna" as m; m.two`, 2},
+		{`import "math" as m; m.one + m.three`, 4},
+		{`import "math" as m; m.sq(6)`, 36},
+		{`import "math" as m; m.Point(1, 2).y`, 2},
+		{`import "math" as m; m.Sign.Negative == m.Sign.Negative`, true},
+		{`import "lib/strings" as s; s.shout("hey")`, "hey!"},
+		{`import "math" as a; import "math" as b; a == b`, true},
+		{`import "math" as m; m.hidden`, "module math has no export: hidden"},
+		{`import "missing" as m;`, "module not found: missing"},
+		{`import "math" as m; m`, "<module math>"},
+		{`let x = 1; x.y`, "member access not supported: INTEGER"},
+		{`if (true) { import "math" as m; m.two }`, 2},
+		{`if (true) { export let x = 1; }`, "export is only allowed at the top level of a module"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		interp := NewInterpreter(WithModulePaths(dir), WithStdout(&out))
+		evaluated := testEvalWith(interp, tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			if actual := inspectResult(evaluated); actual != expected {
+				t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, expected, actual)
+			}
+		}
+
+		// Modules are evaluated at most once per interpreter
+		if strings.Count(out.String(), "loaded math") > 1 {
+			t.Errorf("module evaluated more than once for %q", tt.input)
+		}
+	}
+}
+
+func TestModuleErrors(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"a.tuna":      `import "b" as b; export let x = 1;`,
+		"b.tuna":      `import "a" as a; export let y = 2;`,
+		"self.tuna":   `import "self" as me;`,
+		"broken.tuna": `let = ;`,
+		"fails.tuna":  "let ok = 1;\nlet bad = 1 + true;",
+	})
+	interp := NewInterpreter(WithModulePaths(dir))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import "a" as a;`, "import cycle detected: " + filepath.Join(dir, "a.tuna") + " -> " + filepath.Join(dir, "b.tuna") + " -> " + filepath.Join(dir, "a.tuna")},
+		{`import "self" as s;`, "import cycle detected: " + filepath.Join(dir, "self.tuna") + " -> " + filepath.Join(dir, "self.tuna")},
+		{`import "broken" as b;`, "could not parse module broken"},
+		{`import "fails" as f;`, "type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		errObj, ok := testEvalWith(interp, tt.input).(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q", tt.input)
+			continue
+		}
+
+		if !strings.HasPrefix(errObj.Message, tt.expected) {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+	}
+
+	// Errors within a module are located in the module and traced to the import
+	errObj := testEvalWith(interp, "\nimport \"fails\" as f;").(*object.Error)
+	if errObj.Line != 2 || len(errObj.Stack) != 1 || errObj.Stack[0].Line != 2 || errObj.Stack[0].Function != "<module fails>" {
+		t.Errorf("wrong location of module error. got line=%d stack=%+v", errObj.Line, errObj.Stack)
+	}
+
+	// A failed import is not cached and can be retried
+	if _, ok := testEvalWith(interp, `import "fails" as f;`).(*object.Error); !ok {
+		t.Errorf("failed module import was cached")
+	}
+}
+
+func TestModuleSandboxing(t *testing.T) {
+	outside := writeModules(t, map[string]string{"secret.tuna": `export let secret = "hidden";`, "data.txt": "* private *"})
+	dir := writeModules(t, map[string]string{
+		"lib/escape.tuna": `import "../../outside" as o;`,
+		"lib/inner.tuna":  `import "../top" as top; export let value = top.value;`,
+		"top.tuna":        `export let value = 7;`,
+	})
+	if err := os.Symlink(filepath.Join(outside, "secret.tuna"), filepath.Join(dir, "link.tuna")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Module imports are disabled without module paths
+	errObj, ok := testEvalWith(NewInterpreter(), `import "top" as t;`).(*object.Error)
+	if !ok || errObj.Message != "module imports are disabled" {
+		t.Errorf("module imports were not disabled. got=%+v", errObj)
+	}
+
+	interp := NewInterpreter(WithModulePaths(dir))
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import "` + filepath.Join(outside, "secret") + `" as s;`, "module path must be relative: " + filepath.Join(outside, "secret")},
+		{`import "../` + filepath.Base(outside) + `/secret" as s;`, "module path is outside the module paths: ../" + filepath.Base(outside) + "/secret"},
+		{`import "lib/escape" as e;`, "module path is outside the module paths: ../../outside"},
+		{`import "link" as l;`, "module not found: link"},
+		{`import "../` + filepath.Base(outside) + `/data.txt" as d;`, "module path is outside the module paths: ../" + filepath.Base(outside) + "/data.txt"},
+		{`import "lib/inner" as i; i.value`, "7"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEvalWith(interp, tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+
+	// The content of a module that cannot be parsed is not reported
+	parseDir := writeModules(t, map[string]string{"data.tuna": "* private *"})
+	errObj, ok = testEvalWith(NewInterpreter(WithModulePaths(parseDir)), `import "data" as d;`).(*object.Error)
+	if !ok || strings.Contains(errObj.Message, "*") {
+		t.Errorf("wrong error for unparsable module. got=%+v", errObj)
+	}
+}
+
+func TestInterpolatedStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let name = "Tuna"; "Hello ${name}!"`, "Hello Tuna!"},
+		{`let items = [1, 2, 3]; "you have ${len(items)} items"`, "you have 3 items"},
+		{`"${1 + 2}${true}${[1, "a"]}"`, "3true[1, a]"},
+		{`"${if (false) { 1 }}"`, "null"},
+		{`let x = 2; "outer ${"inner ${x * 2}"}"`, "outer inner 4"},
+		{`"${ {"k": "v"}["k"] }"`, "v"},
+		{`"no interpolation $ {x}"`, "no interpolation $ {x}"},
+		{`let x = 1; "\${x} is ${x}"`, "${x} is 1"},
+		{`"" + "${""}"`, ""},
+		{`str(42) + "!"`, "42!"},
+		{`str("already")`, "already"},
+		{`str([1, 2])`, "[1, 2]"},
+		{`"${missing}"`, "identifier not found: missing"},
+		{`str()`, "wrong number of arguments. got=0, want=1"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+}
+
+func TestMatchExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`match (1) { 1 => "one", 2 => "two", _ => "many" }`, "one"},
+		{`match (5) { 1 => "one", 2 => "two", _ => "many" }`, "many"},
+		{`match (-3) { -3 => "minus three", _ => "other" }`, "minus three"},
+		{`match ("b") { "a" => 1, "b" => 2 }`, "2"},
+		{`match (true) { false => "no", true => "yes" }`, "yes"},
+		{`match (7) { n if n > 5 => n * 2, n => n }`, "14"},
+		{`match (3) { n if n > 5 => n * 2, n => n }`, "3"},
+		{`match ([1, 2]) { [] => "empty", [x] => "one", [x, y] => x + y }`, "3"},
+		{`match ([]) { [] => "empty", _ => "other" }`, "empty"},
+		{`match ([1, 2, 3]) { [first, ...rest] => rest }`, "[2, 3]"},
+		{`match ([1]) { [first, ...rest] => rest }`, "[]"},
+		{`match ([1, 2, 3]) { [1, ...] => "starts with one", _ => "other" }`, "starts with one"},
+		{`match ([1, [2, 3]]) { [a, [b, c]] => a + b + c }`, "6"},
+		{`match ({"kind": "point", "x": 1, "y": 2}) { {"kind": "circle"} => 0, {"kind": "point", "x": x, "y": y} => x + y }`, "3"},
+		{`match ({1: "one"}) { {2: v} => v, {1: v} => v }`, "one"},
+		{`match ("a") { [x] => x, {"a": x} => x, _ => "neither" }`, "neither"},
+		{`match (1) { x => { let y = x + 1; y * 10 } }`, "20"},
+		{`match (1) { x => {} }`, "null"},
+		{`let x = 1; match (2) { x => x }; x`, "1"},
+		{`let f = fn(n) { match (n) { 0 => { return "zero" }, _ => "nonzero" }; "unreachable" }; f(0)`, "zero"},
+		{`match (4) { 1 => "one", 2 => "two" }`, "no match arm matched value: 4"},
+		{`match ([1, 2]) { [x] => x }`, "no match arm matched value: [1, 2]"},
+		{`match (1) { n if missing => n }`, "identifier not found: missing"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+
+	// A value that matches no arm raises a MatchError at the match expression
+	errObj, ok := testEval("\n match (4) { 1 => 1 }").(*object.Error)
+	if !ok || errObj.Kind != object.MATCH_ERROR || errObj.Line != 2 {
+		t.Errorf("wrong match error. got=%+v", errObj)
+	}
+
+	// The bindings of a pattern are checked in the scope of their arm in strict mode
+	tests = []struct {
+		input    string
+		expected string
+	}{
+		{`"use strict"; match ([1, 2]) { [a, a] => a }`, "identifier already declared in this scope: a"},
+		{`"use strict"; match (1) { a => a }; a`, "identifier not declared: a"},
+		{`"use strict"; match (1) { a if b => a }`, "identifier not declared: b"},
+	}
+
+	for _, tt := range tests {
+		errObj, ok := testEval(tt.input).(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q", tt.input)
+			continue
+		}
+
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+	}
+}
+
+func TestDestructuringLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let [a, b] = [1, 2]; a + b`, "3"},
+		{`let [a, b, ...rest] = [1, 2, 3, 4]; rest`, "[3, 4]"},
+		{`let [a, ...rest] = [1]; rest`, "[]"},
+		{`let [first, ...] = [1, 2, 3]; first`, "1"},
+		{`let [_, second] = [1, 2]; second`, "2"},
+		{`let [a, [b, c]] = [1, [2, 3]]; a * b * c`, "6"},
+		{`let [a, b = 10] = [1]; a + b`, "11"},
+		{`let [a, b = a * 2] = [4]; b`, "8"},
+		{`let [a, b = 10] = [1, 2]; b`, "2"},
+		{`let {name, age: years} = {"name": "Tuna", "age": 3}; name + " " + str(years)`, "Tuna 3"},
+		{`let {"full name": n} = {"full name": "Tuna Fish"}; n`, "Tuna Fish"},
+		{`let {name, role = "guest"} = {"name": "Tuna"}; role`, "guest"},
+		{`let {tags: [first, ...]} = {"tags": ["a", "b"]}; first`, "a"},
+		{`let {pos: {x, y} = {"x": 0, "y": 0}} = {}; x + y`, "0"},
+		{`const [a, b] = [1, 2]; let a = 3;`, "cannot reassign constant: a"},
+		{`let [a, b] = [1];`, "not enough elements to destructure: expected 2, got 1"},
+		{`let [a] = [1, 2];`, "too many elements to destructure: expected 1, got 2"},
+		{`let {name} = {"age": 3};`, "key not found: name"},
+		{`let [a] = {"a": 1};`, "cannot destructure MAP as a list"},
+		{`let {a} = [1];`, "cannot destructure LIST as a map"},
+		{`let [a = missing] = [];`, "identifier not found: missing"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+
+	// A mismatch raises a MatchError at the pattern
+	errObj, ok := testEval("let x = 1;\nlet [a, b] = [1];").(*object.Error)
+	if !ok || errObj.Kind != object.MATCH_ERROR || errObj.Line != 2 || errObj.Column != 5 {
+		t.Errorf("wrong destructuring error. got=%+v", errObj)
+	}
+
+	// The names of a destructuring pattern are declared in strict mode
+	tests = []struct {
+		input    string
+		expected string
+	}{
+		{`"use strict"; let [a, a] = [1, 2];`, "identifier already declared in this scope: a"},
+		{`"use strict"; let x = 1; let {x} = {"x": 2};`, "identifier already declared in this scope: x"},
+		{`"use strict"; let [a = b, b] = [];`, "identifier not declared: b"},
+	}
+
+	for _, tt := range tests {
+		errObj, ok := testEval(tt.input).(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q", tt.input)
+			continue
+		}
+
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+	}
+}
+
+func TestStructs(t *testing.T) {
+	declaration := `
+		struct Point {
+			x, y,
+			fn sum() { self.x + self.y }
+			fn scale(k) { Point(self.x * k, self.y * k) }
+			fn add(other) { Point(self.x + other.x, self.y + other.y) }
+		}
+	`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Point(1, 2)`, "Point { x: 1, y: 2 }"},
+		{`Point({"y": 2, "x": 1})`, "Point { x: 1, y: 2 }"},
+		{`Point`, "<struct Point>"},
+		{`Point(1, 2).x`, "1"},
+		{`let p = Point(1, 2); p.y`, "2"},
+		{`Point(1, 2).sum()`, "3"},
+		{`Point(1, 2).scale(10).sum()`, "30"},
+		{`Point(1, 2).add(Point(3, 4))`, "Point { x: 4, y: 6 }"},
+		{`let sum = Point(5, 5).sum; sum()`, "10"},
+		{`Point(1, 2) == Point(1, 2)`, "true"},
+		{`Point(1, 2) == Point(2, 1)`, "false"},
+		{`struct Other { x, y }; Point(1, 2) == Other(1, 2)`, "false"},
+		{`struct Empty {}; Empty()`, "Empty {}"},
+		{`struct Wrapper { value, fn get() { self.value } }; Wrapper([1, 2]).get()`, "[1, 2]"},
+		{`struct Box { v }; Box({"a": 1}).v`, "{a: 1}"},
+		{`struct Box { v }; Box({"v": 1}).v`, "{v: 1}"},
+		{`struct Box { v }; Box(1, 2)`, "wrong number of fields for Box. got=2, want=1"},
+		{`let self = 1; Point(1, 2).sum() + self`, "4"},
+		{`Point(1)`, "wrong number of fields for Point. got=1, want=2"},
+		{`Point({"x": 1, "z": 2})`, "unknown field for Point: z"},
+		{`Point({"x": 1})`, "missing field for Point: y"},
+		{`Point({1: 1})`, "field names must be strings. got=INTEGER"},
+		{`Point(1, 2).z`, "Point has no field or method: z"},
+		{`Point(1, 2).scale()`, "wrong number of arguments for scale. got=0, want=1"},
+		{`Point.x`, "member access not supported: STRUCT"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(declaration + tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+
+	// Errors within a method are traced to the method call
+	errObj, ok := testEval("struct Box { v, fn bad() { self.v + true } }\nBox(1).bad()").(*object.Error)
+	if !ok || len(errObj.Stack) != 1 || errObj.Stack[0].Function != "bad" || errObj.Stack[0].Line != 2 {
+		t.Errorf("wrong traceback of method error. got=%+v", errObj)
+	}
+
+	// Methods may refer to self and the struct in strict mode
+	testIntegerObject(t, testEval(`"use strict"; `+declaration+`Point(1, 2).scale(2).sum()`), 6)
+
+	errObj, ok = testEval(`"use strict"; struct A { x, fn f() { y } }`).(*object.Error)
+	if !ok || errObj.Message != "identifier not declared: y" {
+		t.Errorf("wrong strict mode error. got=%+v", errObj)
+	}
+}
+
+func TestEnums(t *testing.T) {
+	declaration := "enum Shape { Circle(r), Rect(w, h), Empty };\n"
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Shape`, "<enum Shape>"},
+		{`Shape.Circle`, "<variant Shape.Circle>"},
+		{`Shape.Circle(5)`, "Shape.Circle(5)"},
+		{`Shape.Rect(2, "x")`, "Shape.Rect(2, x)"},
+		{`Shape.Empty`, "Shape.Empty"},
+		{`Shape.Rect(2, 3).h`, "3"},
+		{`Shape.Circle(5) == Shape.Circle(5)`, "true"},
+		{`Shape.Circle(5) == Shape.Circle(6)`, "false"},
+		{`Shape.Circle(5) != Shape.Rect(5, 5)`, "true"},
+		{`Shape.Empty == Shape.Empty`, "true"},
+		{`enum Other { Empty }; Shape.Empty == Other.Empty`, "false"},
+		{`Shape.Circle([1, 2]) == Shape.Circle([1, 2])`, "true"},
+		{`if (Shape.Empty == Shape.Empty) { "same" } else { "different" }`, "same"},
+		{`let m = {Shape.Circle(1): "small", Shape.Empty: "none"}; m[Shape.Circle(1)]`, "small"},
+		{`let m = {Shape.Circle(1): "small"}; m[Shape.Circle(2)]`, "null"},
+		{`let m = {Shape.Rect(1, "a"): 1}; m[Shape.Rect(1, "a")]`, "1"},
+		{`Shape.Empty in {Shape.Empty: 0}`, "true"},
+		{`{Shape.Circle(1): 1, Shape.Circle(1): 2}[Shape.Circle(1)] > 0`, "true"},
+		{`Shape.Square`, "enum Shape has no variant: Square"},
+		{`Shape.Circle(5).w`, "Shape.Circle has no field: w"},
+		{`Shape.Circle()`, "wrong number of arguments for Shape.Circle. got=0, want=1"},
+		{`Shape.Empty(1)`, "not a function: ENUM_VALUE"},
+		{`{Shape.Circle([1]): 1}`, "unusable as hash key: ENUM_VALUE"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(declaration + tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+
+	// Enums are declared names in strict mode
+	testIntegerObject(t, testEval(`"use strict"; `+declaration+`Shape.Circle(7).r`), 7)
+}
+
+func TestMethodCalls(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`[1, 2].push(3)`, "[1, 2, 3]"},
+		{`[1, 2].push(3).len()`, "3"},
+		{`let xs = [1, 2, 3]; xs.first() + xs.last()`, "4"},
+		{`[1, 2, 3].tail().tail()`, "[3]"},
+		{`[1, "a", true].join(", ")`, "1, a, true"},
+		{`[].join("-")`, ""},
+		{`"abc".upper()`, "ABC"},
+		{`"ABC".lower()`, "abc"},
+		{`"  pad  ".trim().len()`, "3"},
+		{`"a,b,c".split(",")`, "[a, b, c]"},
+		{`"a,b".split(",").join(" and ").upper()`, "A AND B"},
+		{`let push = [1].push; push(2)`, "[1, 2]"},
+		{`{"a": 1, "b": 2}.len()`, "2"},
+		{`{"a": 1}.has("a")`, "true"},
+		{`{"a": 1}.has("b")`, "false"},
+		{`let person = {"name": "Tuna", "age": 3}; person.name`, "Tuna"},
+		{`{"name": "Tuna"}.missing`, "null"},
+		{`{"len": 10}.len()`, "1"},
+		{`{"f": fn(x) { x * 2 }}.f(21)`, "42"},
+		{`[1].push()`, "wrong number of arguments. got=0, want=1"},
+		{`"a".upper(1)`, "wrong number of arguments. got=1, want=0"},
+		{`[1].join(1)`, "argument to `join` must be STRING, got INTEGER"},
+		{`{"a": 1}.has([1])`, "unusable as hash key: LIST"},
+		{`[1].size()`, "LIST has no method: size"},
+		{`"a".size()`, "STRING has no method: size"},
+		{`5.len()`, "member access not supported: INTEGER"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+
+	// Methods can be registered for any object type
+	interp := NewInterpreter(WithMethod(object.INTEGER_OBJ, "double", func(receiver object.Object, args ...object.Object) object.Object {
+		return &object.Integer{Value: receiver.(*object.Integer).Value * 2}
+	}))
+	testIntegerObject(t, testEvalWith(interp, `let x = 21; x.double()`), 42)
+
+	// Errors raised by methods are located at the call
+	errObj, ok := testEval("\n[1].push()").(*object.Error)
+	if !ok || errObj.Line != 2 || errObj.Column != 9 {
+		t.Errorf("wrong location of method error. got=%+v", errObj)
+	}
+}
+
+func TestFunctionDeclarations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`fn add(a, b) { a + b }; add(1, 2)`, "3"},
+		{`add(1, 2); fn add(a, b) { a + b }`, ""},
+		{`let x = add(1, 2); fn add(a, b) { a + b }; x`, "3"},
+		{`fn isEven(n) { if (n == 0) { true } else { isOdd(n - 1) } }
+		  fn isOdd(n) { if (n == 0) { false } else { isEven(n - 1) } }
+		  isOdd(7)`, "true"},
+		{`let f = fn() { g() + 1; }; fn g() { 41 }; f()`, "42"},
+		{`let outer = fn() { let r = inner(); fn inner() { "hoisted" }; r }; outer()`, "hoisted"},
+		{`if (true) { fn local() { 1 } }; local`, "identifier not found: local"},
+		{`fn counter() { 1 }; counter`, "fn counter() {\n1\n}"},
+		{`fn(x) { x }`, "fn(x) {\nx\n}"},
+		{`fn one(x) { x }; one()`, "wrong number of arguments for one. got=0, want=1"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+
+	// Stack traces show the name of declared functions, even when they are called by another name
+	errObj, ok := testEval("fn broken() { 1 + true }\nlet alias = broken;\nalias()").(*object.Error)
+	if !ok || len(errObj.Stack) != 1 || errObj.Stack[0].Function != "broken" || errObj.Stack[0].Line != 3 {
+		t.Errorf("wrong traceback of declared function. got=%+v", errObj)
+	}
+
+	// Declared functions are hoisted by the strict mode checker
+	testIntegerObject(t, testEval(`"use strict"; let x = f(); fn f() { g() }; fn g() { 5 }; x`), 5)
+
+	errObj, ok = testEval(`"use strict"; let f = 1; fn f() { 2 }`).(*object.Error)
+	if !ok || errObj.Message != "identifier already declared in this scope: f" {
+		t.Errorf("wrong strict mode error. got=%+v", errObj)
+	}
+}
+
+func TestQuoteUnquote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`quote(5)`, `5`},
+		{`quote(5 + 8)`, `(5 + 8)`},
+		{`quote(foobar)`, `foobar`},
+		{`quote(foobar + barfoo)`, `(foobar + barfoo)`},
+		{`quote(unquote(4))`, `4`},
+		{`quote(unquote(4 + 4))`, `8`},
+		{`quote(8 + unquote(4 + 4))`, `(8 + 8)`},
+		{`quote(unquote(4 + 4) + 8)`, `(8 + 8)`},
+		{`quote(unquote(0 - 4))`, `-4`},
+		{`let foobar = 8; quote(foobar)`, `foobar`},
+		{`let foobar = 8; quote(unquote(foobar))`, `8`},
+		{`quote(unquote(true))`, `true`},
+		{`quote(unquote(true == false))`, `false`},
+		{`quote(unquote("tuna"))`, `tuna`},
+		{`quote(unquote(quote(4 + 4)))`, `(4 + 4)`},
+		{`let quoted = quote(4 + 4); quote(unquote(4 + 4) + unquote(quoted))`, `(8 + (4 + 4))`},
+		{`quote(fn(x) { let y = x; y })`, `fn(x) let y = x;y`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		quote, ok := evaluated.(*object.Quote)
+		if !ok {
+			t.Fatalf("expected *object.Quote for %q. got=%T (%+v)", tt.input, evaluated, evaluated)
+		}
+
+		if quote.Node.String() != tt.expected {
+			t.Errorf("not equal. got=%q, want=%q", quote.Node.String(), tt.expected)
+		}
+	}
+
+	evaluated := testEval(`let q = quote(1 + 2); [q, str(q)]`).(*object.List)
+	if evaluated.Elements[0].Inspect() != "quote((1 + 2))" || evaluated.Elements[1].Inspect() != "(1 + 2)" {
+		t.Errorf("wrong inspected and string forms of quote. got=%s", evaluated.Inspect())
+	}
+}
+
+func TestMacroExpansion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let unless = macro(cond, cons, alt) { quote(if (!(unquote(cond))) { unquote(cons) } else { unquote(alt) }) };
+		  unless(10 > 5, "not greater", "greater")`, "greater"},
+		{`let x = double(2); let double = macro(a) { quote(unquote(a) * 2) }; x`, "4"},
+		{`const one = macro() { quote(1) }; one() + one()`, "2"},
+		{`let twice = macro(x) { quote(unquote(x) + unquote(x)) };
+		  let four = macro() { quote(twice(2)) };
+		  four()`, "4"},
+		{`let infix = macro(a, b) { let sum = unquote(a) + unquote(b); quote(unquote(sum)) }; 1`, "1"},
+		{`let count = macro(a, b) { let n = 2; quote(unquote(n)) }; count(undefined, 1 / 0)`, "2"},
+		{`let m = macro() { quote(1) }; m`, "macro m() {\nquote(1)\n}"},
+		{`let assert = macro(cond) { quote(if (!(unquote(cond))) { throw "failed: " + unquote(str(cond)) }) };
+		  try { assert(1 > 2) } catch (e) { e.message }`, "failed: (1 > 2)"},
+
+		// The names bound by an expansion do not capture the names of the spliced code
+		{`let swap = macro(a, b) { quote(fn() { let tmp = unquote(a); [unquote(b), tmp] }()) };
+		  let tmp = 1; let other = 2;
+		  swap(other, tmp)`, "[1, 2]"},
+		{`let apply = macro(f) { quote(fn(x) { unquote(f) }(10)) }; let x = 1; apply(x + 1)`, "2"},
+		{`let first = macro(l) { quote(match (unquote(l)) { [head, ..._] => head }) }; let head = 5; first([head])`, "5"},
+
+		{`let m = macro(a) { quote(unquote(a)) }; m()`, "wrong number of arguments for m. got=0, want=1"},
+		{`let m = macro() { 1 }; m()`, "macro m must return a quote. got=INTEGER"},
+		{`let m = macro() { }; m()`, "macro m must return a quote. got=NULL"},
+		{`let m = macro() { quote(unquote([1])) }; m()`, "cannot unquote LIST"},
+		{`let m = macro() { quote(unquote(missing)) }; m()`, "identifier not found: missing"},
+		{`let m = macro() { quote(m()) }; m()`, "maximum macro expansion depth exceeded: m"},
+		{`let f = fn() { let m = macro() { quote(1) } }; f()`, "macros can only be defined by top-level let statements"},
+		{`quote(1, 2)`, "wrong number of arguments for quote. got=2, want=1"},
+		{`quote(unquote(1, 2))`, "wrong number of arguments for unquote. got=2, want=1"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+
+	// Errors raised within a macro are traced to the expansion
+	errObj, ok := testEval("let m = macro() {\n  1 + true\n};\nm()").(*object.Error)
+	if !ok || errObj.Line != 2 || len(errObj.Stack) != 1 || errObj.Stack[0].Function != "<macro m>" || errObj.Stack[0].Line != 4 {
+		t.Errorf("wrong traceback of macro expansion. got=%+v", errObj)
+	}
+
+	// Macros remain defined in the environment for later programs
+	interp := NewInterpreter()
+	env := object.NewEnvironment()
+	interp.Evaluate(parser.NewParser(lexer.NewLexer(`let inc = macro(x) { quote(unquote(x) + 1) }`)).ParseProgram(), env)
+
+	program := parser.NewParser(lexer.NewLexer(`inc(inc(1))`)).ParseProgram()
+	testIntegerObject(t, interp.Evaluate(program, env), 3)
+
+	// The expansion does not mutate the program
+	if program.String() != "inc(inc(1))" {
+		t.Errorf("program was mutated by the expansion. got=%q", program.String())
+	}
+	testIntegerObject(t, interp.Evaluate(program, env), 3)
+
+	interp.Evaluate(parser.NewParser(lexer.NewLexer(`const m = 1`)).ParseProgram(), env)
+	errObj, ok = interp.Evaluate(parser.NewParser(lexer.NewLexer(`let m = macro() { quote(1) }`)).ParseProgram(), env).(*object.Error)
+	if !ok || errObj.Message != "cannot reassign constant: m" {
+		t.Errorf("macro rebound a constant. got=%+v", errObj)
+	}
+
+	// The strict mode checker checks the expansion and the unquoted expressions of quotes
+	testIntegerObject(t, testEval(`"use strict"; let inc = macro(x) { quote(unquote(x) + 1) }; inc(1)`), 2)
+
+	if quote, ok := testEval(`"use strict"; quote(undeclared + unquote(1))`).(*object.Quote); !ok || quote.Node.String() != "(undeclared + 1)" {
+		t.Errorf("quoted names were checked in strict mode. got=%+v", quote)
+	}
+
+	errObj, ok = testEval(`"use strict"; quote(unquote(undeclared))`).(*object.Error)
+	if !ok || errObj.Message != "identifier not declared: undeclared" {
+		t.Errorf("unquoted names were not checked in strict mode. got=%+v", errObj)
+	}
+}
+
+func TestNullSafeOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`null`, "null"},
+		{`null == null`, "true"},
+		{`0 == null`, "false"},
+		{`null ?? 5`, "5"},
+		{`0 ?? 5`, "0"},
+		{`false ?? 5`, "false"},
+		{`"" ?? 5`, ""},
+		{`null ?? null ?? 3`, "3"},
+		{`1 ?? missing`, "1"},
+		{`let calls = 0; let f = fn() { calls = calls + 1 }; 1 ?? f(); calls`, "0"},
+		{`{"a": 1}["b"] ?? "default"`, "default"},
+		{`let m = null; m?.[1 / 0]`, "null"},
+		{`let m = null; m?.name`, "null"},
+		{`let m = null; m?.[1:missing]`, "null"},
+		{`{"a": {"b": 2}}?.a?.b`, "2"},
+		{`[1, 2, 3]?.[1]`, "2"},
+		{`[1, 2, 3]?.[1:]`, "[2, 3]"},
+		{`"tuna"?.len()`, "4"},
+		{`let m = {"a": null}; m.a?.b ?? "none"`, "none"},
+		{`match (null) { null => "nothing", _ => "something" }`, "nothing"},
+		{`match (0) { null => "nothing", _ => "something" }`, "something"},
+		{`let m = macro() { quote(unquote(null) ?? 7) }; m()`, "7"},
+		{`let m = null; m?.a.b`, "null"},
+		{`let m = null; m?.a.b.c ?? "none"`, "none"},
+		{`let m = null; m?.[0][1]`, "null"},
+		{`let m = null; m?.[0][1 / 0]`, "null"},
+		{`let m = null; m?.[0:1][0]`, "null"},
+		{`let m = null; m?.len()`, "null"},
+		{`let m = null; m?.a.b(missing)`, "null"},
+		{`let m = null; let f = fn(x) { x }; f(m?.a.b) == null`, "true"},
+		{`let m = null; [m?.a.b, 1]`, "[null, 1]"},
+		{`let m = {"a": [[1, 2]]}; m?.a[0][1]`, "2"},
+		{`let m = [[1, 2]]; m?.[0][1]`, "2"},
+		{`"tuna"?.len()`, "4"},
+		{`let m = {"a": null}; m?.a.b`, "member access not supported: NULL"},
+		{`let m = [null]; m?.[0][1]`, "index operator not supported: NULL"},
+		{`let a = null; (a?.x).y`, "member access not supported: NULL"},
+		{`let a = null; (a?.x)[0]`, "index operator not supported: NULL"},
+		{`let a = null; (a?.x)?.y`, "null"},
+		{`let a = null; (a?.x).y ?? "none"`, "member access not supported: NULL"},
+		{`let a = {"x": {"y": 1}}; (a?.x).y`, "1"},
+		{`let m = null; m.a?.b`, "member access not supported: NULL"},
+		{`missing ?? 1`, "identifier not found: missing"},
+		{`"use strict"; let m = null; m?.a ?? null`, "null"},
+	}
+
+	for _, tt := range tests {
+		if actual := inspectResult(testEval(tt.input)); actual != tt.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+}
@@ -1,19 +1,50 @@
 package evaluator
 
 import (
+	"strings"
+
 	"github.com/manishmeganathan/tunalang/object"
 	"github.com/manishmeganathan/tunalang/syntaxtree"
 )
 
-// A function that evaluates a Syntax tree program into an evaluated object
-func evalProgram(program *syntaxtree.Program, env *object.Environment) object.Object {
+// A method of Interpreter that evaluates a Syntax tree program into an evaluated object
+func (in *Interpreter) evalProgram(program *syntaxtree.Program, env *object.Environment) object.Object {
+	// Expand the macros of the program
+	program, err := in.expandMacros(program, env)
+	if err != nil {
+		return err
+	}
+
+	// Check if the program is evaluated in strict mode
+	if in.isStrictProgram(program) {
+		// Statically check the program and return the first diagnostic
+		if errs := in.Check(program, env); len(errs) > 0 {
+			return errs[0]
+		}
+
+		// Enable strict mode for the evaluation of the program
+		previous := in.state.strictProgram
+		in.state.strictProgram = true
+		defer func() { in.state.strictProgram = previous }()
+	}
+
+	// Hoist the function declarations of the program
+	if result := hoistFunctions(program.Statements, env); result != nil {
+		return result
+	}
+
 	// Declare an object
 	var result object.Object
 
 	// Iterate over the program statements
 	for _, statement := range program.Statements {
+		// Evaluate exported declarations as declarations
+		if export, ok := statement.(*syntaxtree.ExportStatement); ok {
+			statement = export.Declaration
+		}
+
 		// Update the result object
-		result = Evaluate(statement, env)
+		result = in.eval(statement, env)
 
 		// Check the type of evaluated object
 		switch result := result.(type) {
@@ -34,15 +65,22 @@ func evalProgram(program *syntaxtree.Program, env *object.Environment) object.Ob
 	return result
 }
 
-// A function that evaluates a Syntax tree block into an evaluated object
-func evalBlockStatement(block *syntaxtree.BlockStatement, env *object.Environment) object.Object {
+// A method of Interpreter that evaluates a Syntax tree block into an evaluated object. The
+// statements are evaluated in the given environment, which is expected to be the scope
+// of the block (a new enclosed environment) such that its bindings do not leak.
+func (in *Interpreter) evalBlockStatement(block *syntaxtree.BlockStatement, env *object.Environment) object.Object {
+	// Hoist the function declarations of the block
+	if result := hoistFunctions(block.Statements, env); result != nil {
+		return result
+	}
+
 	// Declare an object
 	var result object.Object
 
 	// Iterate over the block statements
 	for _, statement := range block.Statements {
 		// Update the result object
-		result = Evaluate(statement, env)
+		result = in.eval(statement, env)
 
 		// Check if result has evaluated object
 		if result != nil {
@@ -61,6 +99,33 @@ func evalBlockStatement(block *syntaxtree.BlockStatement, env *object.Environmen
 	return result
 }
 
+// A function that binds the functions declared by the fn statements in a sequence of statements
+// before the statements are evaluated, such that they can be called (and call each other)
+// regardless of the order of the declarations. Returns an Error if a binding fails.
+func hoistFunctions(statements []syntaxtree.Statement, env *object.Environment) object.Object {
+	// Iterate over the statements
+	for _, statement := range statements {
+		// Check if the statement is an exported declaration
+		if export, ok := statement.(*syntaxtree.ExportStatement); ok {
+			statement = export.Declaration
+		}
+
+		// Check if the statement is a function declaration
+		declaration, ok := statement.(*syntaxtree.FunctionStatement)
+		if !ok {
+			continue
+		}
+
+		// Create the function and bind it to its name
+		function := &object.Function{Name: declaration.Name.Value, Parameters: declaration.Function.Parameters, Body: declaration.Function.Body, Env: env}
+		if result := env.Set(declaration.Name.Value, function); isError(result) {
+			return locateError(result, declaration.Name.Token)
+		}
+	}
+
+	return nil
+}
+
 // A function that evaluates a prefix expression
 // given a prefix operator and an object
 func evalPrefixExpression(operator string, right object.Object) object.Object {
@@ -80,7 +145,7 @@ func evalPrefixExpression(operator string, right object.Object) object.Object {
 	// Unsupported Operator
 	default:
 		// Return Error
-		return object.NewError("unsupported operator: %s%s", operator, right.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unsupported operator: %s%s", operator, right.Type())
 	}
 }
 
@@ -114,7 +179,7 @@ func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
 	// Check that object is an Integer
 	if right.Type() != object.INTEGER_OBJ {
 		// Return Error for non integer objects
-		return object.NewError("unsupported operator: -%s", right.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unsupported operator: -%s", right.Type())
 
 	}
 
@@ -124,9 +189,9 @@ func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
 	return &object.Integer{Value: -value}
 }
 
-// A function that evaluates an infix expression given
+// A method of Interpreter that evaluates an infix expression given
 // a infix operator and the left and right objects
-func evalInfixExpression(operator string, left, right object.Object) object.Object {
+func (in *Interpreter) evalInfixExpression(operator string, left, right object.Object) object.Object {
 	// Check Parameters
 	switch {
 
@@ -135,30 +200,84 @@ func evalInfixExpression(operator string, left, right object.Object) object.Obje
 		// Evaluate expression for integer objects
 		return evalIntegerInfixExpression(operator, left, right)
 
-	// If both objects are Strings
-	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
-		// Evaluate expression for integer objects
-		return evalStringInfixExpression(operator, left, right)
-
 	// If both objects are not Integers but the operator is '=='
 	case operator == "==":
-		// Evaluate the objects for '=='
-		return getNativeBoolean(left == right)
+		// Evaluate the objects for structural equality
+		return getNativeBoolean(object.Equal(left, right))
 
 	// If both objects are not Integers but the operator is '!='
 	case operator == "!=":
-		// Evaluate the objects for '!='
-		return getNativeBoolean(left != right)
+		// Evaluate the objects for structural inequality
+		return getNativeBoolean(!object.Equal(left, right))
+
+	// If the operator is the membership operator
+	case operator == "in":
+		// Evaluate the objects for membership
+		return evalMembershipExpression(left, right)
+
+	// If the operator is an ordering operator
+	case isOrderingOperator(operator):
+		// Evaluate the objects for their ordering
+		return evalOrderingExpression(operator, left, right)
+
+	// If both objects are Strings
+	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
+		// Evaluate expression for string objects
+		return in.evalStringInfixExpression(operator, left, right)
+
+	// If both objects are Lists and the operator is '+'
+	case left.Type() == object.LIST_OBJ && right.Type() == object.LIST_OBJ && operator == "+":
+		// Evaluate the objects for concatenation
+		return in.evalListConcatenation(left, right)
+
+	// If the operator is '*' and one of the objects is an Integer
+	case operator == "*" && (left.Type() == object.INTEGER_OBJ || right.Type() == object.INTEGER_OBJ):
+		// Evaluate the objects for repetition
+		return in.evalRepetitionExpression(left, right)
 
 	// If both objects are not of the same type
 	case left.Type() != right.Type():
 		// Return Error
-		return object.NewError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "type mismatch: %s %s %s", left.Type(), operator, right.Type())
 
 	// Unsupported combination
 	default:
 		// Return Error
-		return object.NewError("unsupported operator: %s %s %s", left.Type(), operator, right.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unsupported operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// A function that returns whether an infix operator is an ordering operator
+func isOrderingOperator(operator string) bool {
+	switch operator {
+	case "<", ">", "<=", ">=":
+		return true
+	default:
+		return false
+	}
+}
+
+// A function that evaluates an ordering infix expression between
+// two objects that have an ordering (such as Strings or Lists)
+func evalOrderingExpression(operator string, left, right object.Object) object.Object {
+	// Compare the objects
+	order, ok := object.Compare(left, right)
+	// Check if the objects could not be compared
+	if !ok {
+		// Return Error
+		return object.NewErrorOfKind(object.TYPE_ERROR, "cannot compare %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	// Check the type of operator
+	switch operator {
+	case "<":
+		return getNativeBoolean(order < 0)
+	case ">":
+		return getNativeBoolean(order > 0)
+	case "<=":
+		return getNativeBoolean(order <= 0)
+	default:
+		return getNativeBoolean(order >= 0)
 	}
 }
 
@@ -202,6 +321,16 @@ func evalIntegerInfixExpression(operator string, left, right object.Object) obje
 		// Evaluate the objects for '>'
 		return getNativeBoolean(leftVal > rightVal)
 
+	// Less Than or Equal To Operator
+	case "<=":
+		// Evaluate the objects for '<='
+		return getNativeBoolean(leftVal <= rightVal)
+
+	// Greater Than or Equal To Operator
+	case ">=":
+		// Evaluate the objects for '>='
+		return getNativeBoolean(leftVal >= rightVal)
+
 	// Equal To Operator
 	case "==":
 		// Evaluate the objects for '=='
@@ -215,31 +344,205 @@ func evalIntegerInfixExpression(operator string, left, right object.Object) obje
 	// Unsupported Operator
 	default:
 		// Return Error
-		return object.NewError("unsupported operator: %s %s %s", left.Type(), operator, right.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unsupported operator: %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
-// A function that evaluates an infix expression between two Strings
+// A method of Interpreter that evaluates an infix expression between two Strings
 // given a infix operator and the left and right String objects
-func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
+func (in *Interpreter) evalStringInfixExpression(operator string, left, right object.Object) object.Object {
 	// Only concatenation is supported
 	if operator != "+" {
 		// Return an error
-		return object.NewError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
 
 	// Retrieve the left and right integer values
 	leftVal := left.(*object.String).Value
 	rightVal := right.(*object.String).Value
 
-	// Return the String Object
-	return &object.String{Value: leftVal + rightVal}
+	// Check that the concatenated String fits within the allocation limit before it is created
+	if err := in.reserve(objectHeaderSize + int64(len(leftVal)+len(rightVal))); err != nil {
+		return err
+	}
+
+	// Return the String Object after accounting for its allocation
+	return in.allocate(&object.String{Value: leftVal + rightVal})
+}
+
+// A method of Interpreter that evaluates the concatenation of two Lists
+func (in *Interpreter) evalListConcatenation(left, right object.Object) object.Object {
+	// Retrieve the left and right elements
+	leftElements := left.(*object.List).Elements
+	rightElements := right.(*object.List).Elements
+
+	// Check that the concatenated List fits within the allocation limit before it is created
+	if err := in.reserve(objectHeaderSize + int64(len(leftElements)+len(rightElements))*listElementSize); err != nil {
+		return err
+	}
+
+	// Copy the elements of both lists into a new slice
+	elements := make([]object.Object, 0, len(leftElements)+len(rightElements))
+	elements = append(elements, leftElements...)
+	elements = append(elements, rightElements...)
+
+	// Return the List Object after accounting for its allocation
+	return in.allocate(&object.List{Elements: elements})
 }
 
-// A function that evaluates an if expression given an IfExpression syntax tree node
-func evalIfExpression(ie *syntaxtree.IfExpression, env *object.Environment) object.Object {
+// A method of Interpreter that evaluates the repetition of a String or a List by an Integer count.
+// The Integer may be on either side of the operator and a count below 1 results in an empty value.
+func (in *Interpreter) evalRepetitionExpression(left, right object.Object) object.Object {
+	// Order the operands such that the Integer count is on the right
+	sequence, countObj := left, right
+	if left.Type() == object.INTEGER_OBJ {
+		sequence, countObj = right, left
+	}
+
+	// Check that the count is an Integer
+	count, ok := countObj.(*object.Integer)
+	if !ok {
+		// Return Error
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unsupported operator: %s * %s", left.Type(), right.Type())
+	}
+
+	// Treat negative counts as zero
+	times := count.Value
+	if times < 0 {
+		times = 0
+	}
+
+	// Check the type of the repeated object
+	switch sequence := sequence.(type) {
+
+	// Repeat the characters of a String
+	case *object.String:
+		// Check that the repeated String fits within the allocation limit
+		if err := in.reserveRepeated(int64(len(sequence.Value)), times, 1); err != nil {
+			return err
+		}
+
+		// Return the String Object after accounting for its allocation
+		return in.allocate(&object.String{Value: strings.Repeat(sequence.Value, int(times))})
+
+	// Repeat the elements of a List
+	case *object.List:
+		// Check that the repeated List fits within the allocation limit
+		if err := in.reserveRepeated(int64(len(sequence.Elements)), times, listElementSize); err != nil {
+			return err
+		}
+
+		// Copy the elements of the list the given number of times
+		elements := make([]object.Object, 0, int64(len(sequence.Elements))*times)
+		for i := int64(0); i < times; i++ {
+			elements = append(elements, sequence.Elements...)
+		}
+
+		// Return the List Object after accounting for its allocation
+		return in.allocate(&object.List{Elements: elements})
+
+	default:
+		// Return Error
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unsupported operator: %s * %s", left.Type(), right.Type())
+	}
+}
+
+// A method of Interpreter that checks whether a value with the given length repeated a number of
+// times can be allocated and returns an out-of-memory Error otherwise. The size of a repeated value
+// is bounded by a hard maximum even without an allocation limit, such that it can always be created.
+func (in *Interpreter) reserveRepeated(length, times, unit int64) object.Object {
+	// Check if the size of the repeated value would exceed the hard maximum
+	if length != 0 && times > maxRepeatedSize/unit/length {
+		// Return an out-of-memory Error
+		return object.NewErrorOfKind(object.MEMORY_ERROR, "out of memory: repeated value is too large")
+	}
+
+	// Check the size of the repeated value against the allocation limit
+	return in.reserve(length * times * unit)
+}
+
+// A function that evaluates the membership of an object in a List (an equal element),
+// a Map (an equal key) or a String (a substring) given the object and the container
+func evalMembershipExpression(item, container object.Object) object.Object {
+	// Check the type of the container
+	switch container := container.(type) {
+
+	// Search the elements of a List
+	case *object.List:
+		for _, element := range container.Elements {
+			// Check if the element is equal to the item
+			if object.Equal(element, item) {
+				return TRUE
+			}
+		}
+
+		return FALSE
+
+	// Search the keys of a Map
+	case *object.Map:
+		// Retrieve the hash key of the item
+		key, ok := object.HashKeyOf(item)
+		if !ok {
+			// Return error
+			return object.NewErrorOfKind(object.TYPE_ERROR, "unusable as hash key: %s", item.Type())
+		}
+
+		// Check if the key exists in the map
+		_, exists := container.Pairs[key]
+		return getNativeBoolean(exists)
+
+	// Search the substrings of a String
+	case *object.String:
+		// Assert that the item is a String
+		substr, ok := item.(*object.String)
+		if !ok {
+			// Return error
+			return object.NewErrorOfKind(object.TYPE_ERROR, "unsupported operator: %s in %s", item.Type(), container.Type())
+		}
+
+		// Check if the string contains the substring
+		return getNativeBoolean(strings.Contains(container.Value, substr.Value))
+
+	default:
+		// Return error
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unsupported operator: %s in %s", item.Type(), container.Type())
+	}
+}
+
+// A method of Interpreter that evaluates an interpolated string given an InterpolatedString syntax
+// tree node. The embedded expressions are evaluated and converted with their string form.
+func (in *Interpreter) evalInterpolatedString(node *syntaxtree.InterpolatedString, env *object.Environment) object.Object {
+	// Declare a string builder
+	var out strings.Builder
+
+	// Iterate over the texts of the string
+	for idx, text := range node.Texts {
+		// Add the text
+		out.WriteString(text)
+
+		// Check if an expression follows the text
+		if idx < len(node.Expressions) {
+			// Evaluate the embedded expression
+			value := in.eval(node.Expressions[idx], env)
+			// Check if evaluated value is an error
+			if isError(value) {
+				// Return the error
+				return value
+			}
+
+			// Add the string form of the value
+			out.WriteString(object.Str(value))
+		}
+	}
+
+	// Return the String Object after accounting for its allocation
+	return in.allocate(&object.String{Value: out.String()})
+}
+
+// A method of Interpreter that evaluates an if expression given an IfExpression syntax tree node
+func (in *Interpreter) evalIfExpression(ie *syntaxtree.IfExpression, env *object.Environment) object.Object {
 	// Evaluate the conditional statement
-	condition := Evaluate(ie.Condition, env)
+	condition := in.eval(ie.Condition, env)
 	// Check if evaluated condition is an error
 	if isError(condition) {
 		// Return the error
@@ -249,12 +552,12 @@ func evalIfExpression(ie *syntaxtree.IfExpression, env *object.Environment) obje
 	// Check if the condition is truthy
 	if isTruthy(condition) {
 		// Evaluate the consequence block
-		return Evaluate(ie.Consequence, env)
+		return in.eval(ie.Consequence, env)
 
 		// Check if alternate exists
 	} else if ie.Alternative != nil {
 		// Evaluate the alternate consequence block
-		return Evaluate(ie.Alternative, env)
+		return in.eval(ie.Alternative, env)
 
 	} else {
 		// Return null
@@ -262,8 +565,8 @@ func evalIfExpression(ie *syntaxtree.IfExpression, env *object.Environment) obje
 	}
 }
 
-// A function that evaluates an identifier literal given an Identifier syntax tree node
-func evalIdentifier(node *syntaxtree.Identifier, env *object.Environment) object.Object {
+// A method of Interpreter that evaluates an identifier literal given an Identifier syntax tree node
+func (in *Interpreter) evalIdentifier(node *syntaxtree.Identifier, env *object.Environment) object.Object {
 	// Check and retrieve the identifier value from the environment
 	if val, ok := env.Get(node.Value); ok {
 		// Return the value
@@ -271,24 +574,24 @@ func evalIdentifier(node *syntaxtree.Identifier, env *object.Environment) object
 	}
 
 	// Check and retrieve the identifer value from the built-ins
-	if builtin, ok := builtins[node.Value]; ok {
+	if builtin, ok := in.builtins[node.Value]; ok {
 		// Return the builtin
 		return builtin
 	}
 
 	// Return error when the identifier does not exist in the environment
-	return object.NewError("identifier not found: " + node.Value)
+	return object.NewErrorOfKind(object.NAME_ERROR, "identifier not found: %s", node.Value)
 }
 
-// A function that evaluates a slice of Expression syntax nodes into evaluated objects
-func evalExpressions(exps []syntaxtree.Expression, env *object.Environment) []object.Object {
+// A method of Interpreter that evaluates a slice of Expression syntax nodes into evaluated objects
+func (in *Interpreter) evalExpressions(exps []syntaxtree.Expression, env *object.Environment) []object.Object {
 	// Declare a result Object slice
 	var result []object.Object
 
 	// Iterate over the expression nodes
 	for _, e := range exps {
 		// Evaluate the expression
-		evaluated := Evaluate(e, env)
+		evaluated := in.eval(e, env)
 
 		// Check for an error
 		if isError(evaluated) {
@@ -304,14 +607,19 @@ func evalExpressions(exps []syntaxtree.Expression, env *object.Environment) []ob
 	return result
 }
 
-// A function that evaluates an IndexExpression between a List and an Integer index
-func evalIndexExpression(left, index object.Object) object.Object {
+// A method of Interpreter that evaluates an IndexExpression on a List, a String or a Map
+func (in *Interpreter) evalIndexExpression(left, index object.Object) object.Object {
 
 	switch {
 	// Check if the left object is a List and the index is an Integer
 	case left.Type() == object.LIST_OBJ && index.Type() == object.INTEGER_OBJ:
 		// Evaluate the list index expression
-		return evalListIndexExpression(left, index)
+		return in.evalListIndexExpression(left, index)
+
+	// Check if the left object is a String and the index is an Integer
+	case left.Type() == object.STRING_OBJ && index.Type() == object.INTEGER_OBJ:
+		// Evaluate the string index expression
+		return in.evalStringIndexExpression(left, index)
 
 	// Check if the left object is a Map
 	case left.Type() == object.MAP_OBJ:
@@ -320,65 +628,114 @@ func evalIndexExpression(left, index object.Object) object.Object {
 
 	default:
 		// Return error
-		return object.NewError("index operator not supported: %s", left.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "index operator not supported: %s", left.Type())
 	}
 }
 
-// A function that evaluates a List index expression given a List and an Integer index
-func evalListIndexExpression(list, index object.Object) object.Object {
+// A method of Interpreter that evaluates a List index expression given a List and an Integer index.
+// Negative indices count from the end of the List.
+func (in *Interpreter) evalListIndexExpression(list, index object.Object) object.Object {
 	// Assert the list object as a List
 	listObject := list.(*object.List)
 	// Assert the index object as an Integer
 	idx := index.(*object.Integer).Value
 
-	// Check if the index is out of range
-	max := int64(len(listObject.Elements) - 1)
-	if idx < 0 || idx > max {
-		// Return null
-		return NULL
+	// Resolve the index against the length of the list
+	position, ok := resolveIndex(idx, len(listObject.Elements))
+	if !ok {
+		// Return an error in strict mode or null otherwise
+		return in.indexOutOfRange(idx, len(listObject.Elements))
 	}
 
 	// Return the list element at the index
-	return listObject.Elements[idx]
+	return listObject.Elements[position]
+}
+
+// A method of Interpreter that evaluates a String index expression given a String and an Integer
+// index. Strings are indexed by character (Unicode code point) and negative indices count from
+// the end of the String. The character at the index is returned as a String.
+func (in *Interpreter) evalStringIndexExpression(str, index object.Object) object.Object {
+	// Retrieve the characters of the string
+	chars := []rune(str.(*object.String).Value)
+	// Assert the index object as an Integer
+	idx := index.(*object.Integer).Value
+
+	// Resolve the index against the number of characters
+	position, ok := resolveIndex(idx, len(chars))
+	if !ok {
+		// Return an error in strict mode or null otherwise
+		return in.indexOutOfRange(idx, len(chars))
+	}
+
+	// Return the String Object of the character after accounting for its allocation
+	return in.allocate(&object.String{Value: string(chars[position])})
+}
+
+// A function that resolves an index (which is negative if it counts from the end)
+// against a length and returns the position and whether it is within range
+func resolveIndex(idx int64, length int) (int64, bool) {
+	// Count negative indices from the end
+	if idx < 0 {
+		idx += int64(length)
+	}
+
+	// Check if the index is out of range
+	if idx < 0 || idx >= int64(length) {
+		return 0, false
+	}
+
+	return idx, true
+}
+
+// A method of Interpreter that returns the result of an out-of-range index.
+// It is an Error in strict mode and null otherwise.
+func (in *Interpreter) indexOutOfRange(idx int64, length int) object.Object {
+	// Check if the interpreter or the program is in strict mode
+	if in.Strict || in.state.strictProgram {
+		// Return Error
+		return object.NewErrorOfKind(object.INDEX_ERROR, "index out of range: %d (length %d)", idx, length)
+	}
+
+	// Return null
+	return NULL
 }
 
-func evalMapLiteral(node *syntaxtree.MapLiteral, env *object.Environment) object.Object {
+func (in *Interpreter) evalMapLiteral(node *syntaxtree.MapLiteral, env *object.Environment) object.Object {
 	// Init a new mapping for HashKeys to MapPairs
 	pairs := make(map[object.HashKey]object.MapPair)
 
 	// Iterate keys and values in the map literal
 	for keyNode, valueNode := range node.Pairs {
 		// Evaluate the key
-		key := Evaluate(keyNode, env)
+		key := in.eval(keyNode, env)
 		// Check for an error
 		if isError(key) {
 			// Return the error
 			return key
 		}
 
-		// Assert that the key is a Hashable
-		hashKey, ok := key.(object.Hashable)
+		// Retrieve the hash key of the key
+		hashed, ok := object.HashKeyOf(key)
 		if !ok {
 			// Return error
-			return object.NewError("unusable as hash key: %s", key.Type())
+			return object.NewErrorOfKind(object.TYPE_ERROR, "unusable as hash key: %s", key.Type())
 		}
 
 		// Evaluate the value
-		value := Evaluate(valueNode, env)
+		value := in.eval(valueNode, env)
 		// Check for an error
 		if isError(value) {
 			// Return the error
 			return value
 		}
 
-		// Generate the hash key for the key
-		hashed := hashKey.HashKey()
 		// Create a MapPair for the key and value and add it to the pairs map
 		pairs[hashed] = object.MapPair{Key: key, Value: value}
 	}
 
 	// Create a new HashMap from the pairs map and return it
-	return &object.Map{Pairs: pairs}
+	// after accounting for its allocation
+	return in.allocate(&object.Map{Pairs: pairs})
 }
 
 // A function that evaluates a Map index expression given a Map and a Hashable index
@@ -386,15 +743,15 @@ func evalMapIndexExpression(mapobj, index object.Object) object.Object {
 	// Assert the map object as a Map
 	mapObject := mapobj.(*object.Map)
 
-	// Assert the index object as a Hashable
-	key, ok := index.(object.Hashable)
+	// Retrieve the hash key of the index object
+	key, ok := object.HashKeyOf(index)
 	if !ok {
 		// Return error
-		return object.NewError("unusable as hash key: %s", index.Type())
+		return object.NewErrorOfKind(object.TYPE_ERROR, "unusable as hash key: %s", index.Type())
 	}
 
 	// Retrieve the MapPair from the map object for the hash key
-	pair, ok := mapObject.Pairs[key.HashKey()]
+	pair, ok := mapObject.Pairs[key]
 	if !ok {
 		// Return null when not found
 		return NULL
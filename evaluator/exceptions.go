@@ -0,0 +1,160 @@
+package evaluator
+
+import (
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// A method of Interpreter that evaluates a try expression given a TryExpression syntax tree node.
+// An error raised by the tried block is caught by the catch block and the finally block is always
+// evaluated. An error or return from the finally block replaces the result of the expression.
+// Cancellations and out-of-memory errors abort the evaluation and are not caught.
+func (in *Interpreter) evalTryExpression(te *syntaxtree.TryExpression, env *object.Environment) object.Object {
+	// Evaluate the tried block
+	result := in.eval(te.Block, env)
+
+	// Check if the tried block raised a catchable error and a catch block exists
+	if errObj, ok := result.(*object.Error); ok && te.Catch != nil && isCatchable(errObj) {
+		// Bind the caught value in a new scope for the catch block
+		catchEnv := object.NewEnclosedEnvironment(env)
+		catchEnv.Set(te.Parameter.Value, caughtValue(errObj))
+		// Evaluate the catch block in the scope of the caught value
+		result = in.evalBlockStatement(te.Catch, catchEnv)
+	}
+
+	// Check if a finally block exists
+	if te.Finally != nil {
+		// Evaluate the finally block
+		final := in.eval(te.Finally, env)
+		// Check if the finally block raised an error or returned
+		if final != nil && (final.Type() == object.ERROR_OBJ || final.Type() == object.RETURN_VALUE_OBJ) {
+			// Return the object of the finally block
+			return final
+		}
+	}
+
+	// Return null for empty blocks
+	if result == nil {
+		return NULL
+	}
+
+	// Return the result of the expression
+	return result
+}
+
+// A function that returns whether an Error object can be caught by a catch block. Cancellations
+// and out-of-memory errors abort the evaluation (the allocation limit is exceeded for its remainder),
+// while thrown values can always be caught (even if their kind is that of an aborting error).
+func isCatchable(err *object.Error) bool {
+	return err.Value != nil || (err.Kind != object.CANCELLED_ERROR && err.Kind != object.MEMORY_ERROR)
+}
+
+// A function that creates an Error object for a value thrown by a throw statement. A thrown
+// Map with "message" and "kind" String keys sets the message and kind, and a rethrown caught
+// error (a Map with the keys of a caught error) rethrows the value that was originally thrown.
+func newThrownError(val object.Object) *object.Error {
+	// Create a thrown error with the value
+	err := &object.Error{Kind: object.THROWN_ERROR, Message: val.Inspect(), Value: val}
+
+	// Check if the value is a Map
+	if mapObject, ok := val.(*object.Map); ok {
+		// Retrieve the message and kind of the error from the map
+		if message, ok := mapStringValue(mapObject, "message"); ok {
+			err.Message = message
+		}
+		if kind, ok := mapStringValue(mapObject, "kind"); ok {
+			err.Kind = kind
+		}
+
+		// Retrieve the originally thrown value of a rethrown caught error
+		if isCaughtValue(mapObject) {
+			err.Value = mapObject.Pairs[(&object.String{Value: "value"}).HashKey()].Value
+		}
+	}
+
+	// Return the error
+	return err
+}
+
+// Represents the keys of the Map bound by a catch block
+var caughtKeys = []string{"message", "kind", "line", "column", "stack", "value"}
+
+// A function that returns the value bound by a catch block for a caught Error object, which is a Map
+// with the message, kind, position and stack of the error and the thrown value (null for runtime
+// errors). The stack is a List of Maps with the function, line and column of each call.
+func caughtValue(err *object.Error) object.Object {
+	// Retrieve the thrown value if it exists
+	var value object.Object = NULL
+	if err.Value != nil {
+		value = err.Value
+	}
+
+	// Convert the stack frames into a list of maps
+	stack := make([]object.Object, len(err.Stack))
+	for idx, frame := range err.Stack {
+		stack[idx] = newStringMap(map[string]object.Object{
+			"function": &object.String{Value: frame.Function},
+			"line":     &object.Integer{Value: int64(frame.Line)},
+			"column":   &object.Integer{Value: int64(frame.Column)},
+		})
+	}
+
+	// Return the error as a map
+	return newStringMap(map[string]object.Object{
+		"message": &object.String{Value: err.Message},
+		"kind":    &object.String{Value: err.Kind},
+		"line":    &object.Integer{Value: int64(err.Line)},
+		"column":  &object.Integer{Value: int64(err.Column)},
+		"stack":   &object.List{Elements: stack},
+		"value":   value,
+	})
+}
+
+// A function that returns whether a Map object has exactly the keys of a value bound by a catch block
+func isCaughtValue(mapObject *object.Map) bool {
+	// Check the number of keys
+	if len(mapObject.Pairs) != len(caughtKeys) {
+		return false
+	}
+
+	// Check that each key exists
+	for _, key := range caughtKeys {
+		if _, ok := mapObject.Pairs[(&object.String{Value: key}).HashKey()]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// A function that creates a Map object with String keys from a Go map of objects
+func newStringMap(values map[string]object.Object) *object.Map {
+	// Init a new mapping for HashKeys to MapPairs
+	pairs := make(map[object.HashKey]object.MapPair, len(values))
+
+	// Iterate over the values and add them as pairs
+	for key, value := range values {
+		keyObj := &object.String{Value: key}
+		pairs[keyObj.HashKey()] = object.MapPair{Key: keyObj, Value: value}
+	}
+
+	// Return the map object
+	return &object.Map{Pairs: pairs}
+}
+
+// A function that retrieves the String value for a String key of a Map object
+func mapStringValue(mapObject *object.Map, key string) (string, bool) {
+	// Retrieve the pair for the key
+	pair, ok := mapObject.Pairs[(&object.String{Value: key}).HashKey()]
+	if !ok {
+		return "", false
+	}
+
+	// Check that the value is a String
+	str, ok := pair.Value.(*object.String)
+	if !ok {
+		return "", false
+	}
+
+	return str.Value, true
+}
@@ -0,0 +1,282 @@
+package evaluator
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// A structure that represents the resource limits of an Interpreter
+type Limits struct {
+	// Represents the maximum number of bytes that may be allocated (0 is unlimited)
+	MaxAllocation int64
+}
+
+// A structure that represents a Tuna Interpreter. Each Interpreter has its own
+// builtins, input/output streams and resource limits. An Interpreter is safe for
+// use by multiple goroutines, evaluation is serialized by a lock that is released
+// while built-in functions run such that they can call back into the Interpreter.
+type Interpreter struct {
+	// Represents the writer used for standard output
+	Stdout io.Writer
+
+	// Represents the writer used for standard error
+	Stderr io.Writer
+
+	// Represents the reader used for standard input
+	Stdin io.Reader
+
+	// Represents the resource limits of the interpreter, which are applied to each
+	// top-level evaluation (a call of Evaluate or Call while no other evaluation is running)
+	Limits Limits
+
+	// Represents whether the interpreter runs in strict mode, which statically checks programs
+	// for redeclared and undeclared names and reports out-of-range indices as errors.
+	// Strict mode can also be enabled for a single program with a "use strict" directive.
+	Strict bool
+
+	// Represents the registry of built-in functions
+	builtins map[string]*object.Builtin
+
+	// Represents the method tables of the object types
+	methods map[object.ObjectType]map[string]object.MethodFunction
+
+	// Represents the allocation meter of the current top-level evaluation
+	meter *AllocationMeter
+
+	// Represents the number of evaluations in progress (nested or concurrent), which share the allocation meter
+	active int
+
+	// Represents the buffered reader over the standard input, its source and its lock
+	reader       *bufio.Reader
+	readerSource io.Reader
+	stdinMu      sync.Mutex
+
+	// Represents the directories searched for imported modules. Module imports are
+	// disabled without module paths and imported files must be within one of them.
+	ModulePaths []string
+
+	// Represents the cache of evaluated modules by their resolved path
+	modules map[string]*object.Module
+
+	// Represents the number of unique names generated for the bindings of macro expansions
+	gensyms int
+
+	// Represents the lock that serializes evaluation
+	mu sync.Mutex
+
+	// Represents the state of the current evaluation
+	state evalState
+}
+
+// A structure that represents the state of the current evaluation of an Interpreter. It
+// is retained while the evaluation lock is released for a built-in function and restored
+// after it, such that other evaluations in the meantime do not affect it.
+type evalState struct {
+	// Represents the context of the evaluation
+	ctx context.Context
+
+	// Represents whether the current program enabled strict mode with a directive
+	strictProgram bool
+
+	// Represents the resolved paths of the modules being loaded (the innermost last)
+	importing []string
+
+	// Represents whether a macro is being expanded (the quotes of an expansion are hygienic)
+	expanding bool
+}
+
+// Represents an alias for an Interpreter configuration option
+type Option func(*Interpreter)
+
+// A function that returns an Option to set the standard output writer
+func WithStdout(w io.Writer) Option {
+	return func(in *Interpreter) { in.Stdout = w }
+}
+
+// A function that returns an Option to set the standard error writer
+func WithStderr(w io.Writer) Option {
+	return func(in *Interpreter) { in.Stderr = w }
+}
+
+// A function that returns an Option to set the standard input reader
+func WithStdin(r io.Reader) Option {
+	return func(in *Interpreter) { in.Stdin = r }
+}
+
+// A function that returns an Option to set the resource limits
+func WithLimits(limits Limits) Option {
+	return func(in *Interpreter) { in.Limits = limits }
+}
+
+// A function that returns an Option to enable or disable strict mode
+func WithStrictMode(strict bool) Option {
+	return func(in *Interpreter) { in.Strict = strict }
+}
+
+// A function that returns an Option to set the directories that are searched for imported modules,
+// which enables module imports. Modules are searched in the directory of the importing module first.
+func WithModulePaths(paths ...string) Option {
+	return func(in *Interpreter) { in.ModulePaths = paths }
+}
+
+// A function that returns an Option to register a built-in function.
+// A built-in function with the same name as a default built-in replaces it.
+func WithBuiltin(name string, fn object.BuiltinFunction) Option {
+	return func(in *Interpreter) { in.RegisterBuiltin(name, fn) }
+}
+
+// A function that returns an Option to register a method for the objects of a type.
+// A method with the same name as a default method of the type replaces it.
+func WithMethod(objType object.ObjectType, name string, fn object.MethodFunction) Option {
+	return func(in *Interpreter) { in.RegisterMethod(objType, name, fn) }
+}
+
+// The Interpreter used by the package level compatibility functions
+var defaultInterpreter = NewInterpreter()
+
+// A constructor function that generates and returns a new
+// Interpreter after applying the given configuration options
+func NewInterpreter(opts ...Option) *Interpreter {
+	// Construct an interpreter with the process streams
+	in := &Interpreter{
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
+		Stdin:   os.Stdin,
+		modules: make(map[string]*object.Module),
+		state:   evalState{ctx: context.Background()},
+	}
+	// Register the default built-in functions and methods
+	in.builtins = newBuiltins(in)
+	in.methods = newMethods(in, in.builtins)
+
+	// Apply the configuration options
+	for _, opt := range opts {
+		opt(in)
+	}
+
+	// Initialize the allocation meter with the configured limit
+	in.meter = &AllocationMeter{Limit: in.Limits.MaxAllocation}
+	// Return the interpreter
+	return in
+}
+
+// A method of Interpreter that registers a built-in function with the given name
+func (in *Interpreter) RegisterBuiltin(name string, fn object.BuiltinFunction) {
+	in.Exclusive(func() { in.builtins[name] = &object.Builtin{Fn: fn} })
+}
+
+// A method of Interpreter that registers a method with the given name for the objects of a type
+func (in *Interpreter) RegisterMethod(objType object.ObjectType, name string, fn object.MethodFunction) {
+	in.Exclusive(func() {
+		// Create the method table of the type if it has none
+		if in.methods[objType] == nil {
+			in.methods[objType] = make(map[string]object.MethodFunction)
+		}
+
+		in.methods[objType][name] = fn
+	})
+}
+
+// A method of Interpreter that retrieves a built-in function by its name
+func (in *Interpreter) Builtin(name string) (builtin *object.Builtin, ok bool) {
+	in.Exclusive(func() { builtin, ok = in.builtins[name] })
+	return builtin, ok
+}
+
+// A method of Interpreter that runs a function while holding the evaluation lock, such that it
+// can safely access the state shared with evaluations (such as the environments they evaluate in).
+// It must not be called from within an evaluation, except from a built-in function (which runs
+// with the lock released), and the function must not evaluate with the Interpreter.
+func (in *Interpreter) Exclusive(fn func()) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	fn()
+}
+
+// A method of Interpreter that evaluates a Syntax Tree given
+// a node on it and returns an evaluated object
+func (in *Interpreter) Evaluate(node syntaxtree.Node, env *object.Environment) object.Object {
+	// Acquire the evaluation lock with a background context
+	restore := in.enter(context.Background())
+	defer restore()
+
+	// Evaluate the node
+	return in.eval(node, env)
+}
+
+// A method of Interpreter that calls a callable object (a Function or a Builtin) with the given
+// arguments and returns its unwrapped result. The evaluation is aborted if the context is cancelled.
+// A runtime error is returned as an *object.Error and a cancellation as the error of the context.
+func (in *Interpreter) Call(ctx context.Context, fn object.Object, args ...object.Object) (object.Object, error) {
+	// Check if the context has already been cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Acquire the evaluation lock with the given context
+	restore := in.enter(ctx)
+	defer restore()
+
+	// Apply the function on the arguments
+	result := unwrapReturnValue(in.applyFunction(fn, args))
+
+	// Check if the call resulted in an error
+	if errObj, ok := result.(*object.Error); ok {
+		// Return the context error if the call was cancelled
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return nil, errObj
+	}
+
+	// Return the result
+	return result, nil
+}
+
+// A method of Interpreter that acquires the evaluation lock and sets the context of the evaluation.
+// A top-level evaluation starts with a new allocation meter for the configured limits, while an
+// evaluation that starts during another (such as a callback from a built-in function) shares its
+// meter. It returns a function that restores the previous evaluation state and releases the lock.
+func (in *Interpreter) enter(ctx context.Context) func() {
+	in.mu.Lock()
+	previous := in.state
+	in.state.ctx = ctx
+
+	// Reset the allocation meter if no other evaluation is in progress
+	if in.active == 0 {
+		in.meter = &AllocationMeter{Limit: in.Limits.MaxAllocation}
+	}
+	in.active++
+
+	return func() {
+		in.active--
+		in.state = previous
+		in.mu.Unlock()
+	}
+}
+
+// A method of Interpreter that calls a built-in function with the given arguments.
+// The evaluation lock is released during the call such that the built-in function
+// can call back into the Interpreter (for example, with a Function argument).
+func (in *Interpreter) callBuiltin(fn *object.Builtin, args []object.Object) object.Object {
+	// Release the evaluation lock and retain the current evaluation state
+	state := in.state
+	in.mu.Unlock()
+
+	// Reacquire the lock and restore the evaluation state after the call
+	defer func() {
+		in.mu.Lock()
+		in.state = state
+	}()
+
+	// Call the built-in function
+	return fn.Fn(args...)
+}
@@ -0,0 +1,387 @@
+package evaluator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/manishmeganathan/tunalang/lexer"
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// Represents the names of the special forms that quote a node and splice an evaluated node into a quote
+const (
+	quoteName   = "quote"
+	unquoteName = "unquote"
+)
+
+// Represents the maximum depth of nested macro expansions (macros that expand into macro calls)
+const maxExpansionDepth = 64
+
+// A method of Interpreter that expands the macros of a program before it is evaluated. The top-level
+// let statements that bind macro literals define macros in the given environment and are removed
+// from the program, then the calls of the macros are replaced by their expansions. Returns the
+// expanded program (the given program is not mutated) or an Error if the expansion failed.
+func (in *Interpreter) expandMacros(program *syntaxtree.Program, env *object.Environment) (*syntaxtree.Program, object.Object) {
+	// Define the macros of the program and collect the other statements
+	statements := []syntaxtree.Statement{}
+	for _, statement := range program.Statements {
+		defined, err := defineMacro(statement, env)
+		if err != nil {
+			return nil, err
+		}
+
+		if !defined {
+			statements = append(statements, statement)
+		}
+	}
+
+	// Expand the calls of the macros in the remaining statements
+	expanded, err := in.expandNode(&syntaxtree.Program{Statements: statements}, env, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return expanded.(*syntaxtree.Program), nil
+}
+
+// A function that defines a macro in the environment if the statement is a let statement
+// that binds a macro literal. Returns whether a macro was defined or an Error if the binding failed.
+func defineMacro(statement syntaxtree.Statement, env *object.Environment) (bool, object.Object) {
+	// Check if the statement binds a macro literal to a name
+	let, ok := statement.(*syntaxtree.LetStatement)
+	if !ok || let.Name == nil {
+		return false, nil
+	}
+	literal, ok := let.Value.(*syntaxtree.MacroLiteral)
+	if !ok {
+		return false, nil
+	}
+
+	// Create the macro and bind it to the name
+	macro := &object.Macro{Name: let.Name.Value, Parameters: literal.Parameters, Body: literal.Body, Env: env}
+
+	var result object.Object
+	if let.IsConst() {
+		result = env.SetConst(let.Name.Value, macro)
+	} else {
+		result = env.Set(let.Name.Value, macro)
+	}
+
+	// Check if the binding failed (the name is a constant)
+	if isError(result) {
+		return false, locateError(result, let.Name.Token)
+	}
+
+	return true, nil
+}
+
+// A method of Interpreter that replaces the macro calls in the syntax tree rooted at a node with their
+// expansions, which are expanded as well until the maximum expansion depth. Returns the expanded node
+// or an Error if an expansion failed.
+func (in *Interpreter) expandNode(node syntaxtree.Node, env *object.Environment, depth int) (syntaxtree.Node, object.Object) {
+	// Declare the error of a failed expansion
+	var failure object.Object
+
+	expanded := syntaxtree.Modify(node, func(node syntaxtree.Node) syntaxtree.Node {
+		// Skip the remaining nodes after a failed expansion
+		if failure != nil {
+			return node
+		}
+
+		// Check if the node is a call of a macro
+		call, macro, ok := macroCall(node, env)
+		if !ok {
+			return node
+		}
+
+		// Check the depth of the expansion
+		if depth >= maxExpansionDepth {
+			failure = locateError(object.NewErrorOfKind(object.MACRO_ERROR, "maximum macro expansion depth exceeded: %s", macro.Name), call.Token)
+			return node
+		}
+
+		// Expand the call of the macro
+		expansion, err := in.applyMacro(macro, call)
+		if err != nil {
+			failure = err
+			return node
+		}
+
+		// Expand the macro calls in the expansion
+		expansion, failure = in.expandNode(expansion, env, depth+1)
+		if failure != nil {
+			return node
+		}
+
+		return expansion
+	})
+
+	return expanded, failure
+}
+
+// A function that returns the call expression and the macro if a node is a call of a macro bound in the environment
+func macroCall(node syntaxtree.Node, env *object.Environment) (*syntaxtree.CallExpression, *object.Macro, bool) {
+	// Check if the node is a call of a name
+	call, ok := node.(*syntaxtree.CallExpression)
+	if !ok {
+		return nil, nil, false
+	}
+	ident, ok := call.Function.(*syntaxtree.Identifier)
+	if !ok {
+		return nil, nil, false
+	}
+
+	// Check if the name is bound to a macro
+	obj, ok := env.Get(ident.Value)
+	if !ok {
+		return nil, nil, false
+	}
+	macro, ok := obj.(*object.Macro)
+
+	return call, macro, ok
+}
+
+// A method of Interpreter that expands a macro call by evaluating the body of the macro with its
+// parameters bound to the quoted arguments of the call. The body must evaluate to a Quote, whose
+// node replaces the call. Returns the node of the Quote or an Error if the expansion failed.
+func (in *Interpreter) applyMacro(macro *object.Macro, call *syntaxtree.CallExpression) (syntaxtree.Node, object.Object) {
+	// Check the number of arguments
+	if len(call.Arguments) != len(macro.Parameters) {
+		return nil, locateError(object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments for %s. got=%d, want=%d", macro.Name, len(call.Arguments), len(macro.Parameters)), call.Token)
+	}
+
+	// Bind the quoted arguments to the parameters in the scope of the macro
+	env := object.NewEnclosedEnvironment(macro.Env)
+	for idx, param := range macro.Parameters {
+		env.Set(param.Value, &object.Quote{Node: call.Arguments[idx]})
+	}
+
+	// Evaluate the body of the macro, with hygienic quotes
+	expanding := in.state.expanding
+	in.state.expanding = true
+	evaluated := unwrapReturnValue(in.evalBlockStatement(macro.Body, env))
+	in.state.expanding = expanding
+
+	// Check if the evaluation of the body failed
+	if errObj, ok := evaluated.(*object.Error); ok {
+		// Locate errors that did not occur within the body of the macro at the call
+		if errObj.Line == 0 {
+			return nil, locateError(errObj, call.Token)
+		}
+
+		// Add the expansion to the stack of errors raised within the macro
+		errObj.Stack = append(errObj.Stack, object.Frame{
+			Function: "<macro " + macro.Name + ">",
+			Line:     call.Token.Line,
+			Column:   call.Token.Column,
+		})
+
+		return nil, errObj
+	}
+
+	// Check that the body evaluated to a Quote
+	quote, ok := evaluated.(*object.Quote)
+	if !ok {
+		if evaluated == nil {
+			evaluated = NULL
+		}
+		return nil, locateError(object.NewErrorOfKind(object.MACRO_ERROR, "macro %s must return a quote. got=%s", macro.Name, evaluated.Type()), call.Token)
+	}
+
+	return quote.Node, nil
+}
+
+// A method of Interpreter that evaluates a quote call into a Quote of its argument. The argument is
+// not evaluated, except for the arguments of the unquote calls within it, whose objects are converted
+// back into nodes and spliced into the quote. The names bound within a quote that is evaluated by a
+// macro expansion are renamed to unique names throughout the quote (excluding the spliced nodes),
+// such that the expansion does not capture the names of the code that is spliced into it.
+func (in *Interpreter) evalQuote(call *syntaxtree.CallExpression, env *object.Environment) object.Object {
+	// Check the number of arguments
+	if len(call.Arguments) != 1 {
+		return locateError(object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments for %s. got=%d, want=1", quoteName, len(call.Arguments)), call.Token)
+	}
+
+	// Declare the error of a failed unquote
+	var failure object.Object
+	// Replace the unquote calls with placeholders for the nodes of their evaluated arguments
+	spliced := make(map[*syntaxtree.Identifier]syntaxtree.Node)
+	quoted := syntaxtree.Modify(call.Arguments[0], func(node syntaxtree.Node) syntaxtree.Node {
+		// Check if the node is an unquote call
+		if failure != nil || !isSpecialForm(node, unquoteName) {
+			return node
+		}
+		unquote := node.(*syntaxtree.CallExpression)
+
+		// Check the number of arguments
+		if len(unquote.Arguments) != 1 {
+			failure = locateError(object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments for %s. got=%d, want=1", unquoteName, len(unquote.Arguments)), unquote.Token)
+			return node
+		}
+
+		// Evaluate the argument of the unquote call
+		evaluated := in.eval(unquote.Arguments[0], env)
+		// Check if evaluated argument is an error
+		if isError(evaluated) {
+			failure = evaluated
+			return node
+		}
+
+		// Convert the evaluated object into a node
+		unquoted, err := unquoteNode(evaluated, unquote.Token)
+		if err != nil {
+			failure = err
+			return node
+		}
+
+		// Replace the unquote call with a placeholder for the node
+		placeholder := &syntaxtree.Identifier{Token: unquote.Token, Value: unquoteName}
+		spliced[placeholder] = unquoted
+		return placeholder
+	})
+
+	// Check if an unquote failed
+	if failure != nil {
+		return failure
+	}
+
+	// Rename the names bound within the quote of a macro expansion
+	if in.state.expanding {
+		quoted = in.renameBindings(quoted)
+	}
+
+	// Replace the placeholders with the nodes of the unquote calls
+	quoted = syntaxtree.Modify(quoted, func(node syntaxtree.Node) syntaxtree.Node {
+		if placeholder, ok := node.(*syntaxtree.Identifier); ok {
+			if unquoted, ok := spliced[placeholder]; ok {
+				return unquoted
+			}
+		}
+
+		return node
+	})
+
+	// Return the Quote Object
+	return &object.Quote{Node: quoted}
+}
+
+// A function that returns whether a node is a call of the special form with the given name
+func isSpecialForm(node syntaxtree.Node, name string) bool {
+	// Check if the node is a call of the name
+	call, ok := node.(*syntaxtree.CallExpression)
+	if !ok {
+		return false
+	}
+
+	ident, ok := call.Function.(*syntaxtree.Identifier)
+	return ok && ident.Value == name
+}
+
+// A function that converts an unquoted object into a syntax tree node given the token of the unquote
+// call. A Quote is converted into its node and Integer, Boolean, String and Null objects into literals.
+func unquoteNode(obj object.Object, tok lexer.Token) (syntaxtree.Node, object.Object) {
+	// Check the type of the object
+	switch obj := obj.(type) {
+
+	// Quote objects
+	case *object.Quote:
+		return obj.Node, nil
+
+	// Integer objects
+	case *object.Integer:
+		tok.Type, tok.Literal = lexer.INT, strconv.FormatInt(obj.Value, 10)
+		return &syntaxtree.IntegerLiteral{Token: tok, Value: obj.Value}, nil
+
+	// Boolean objects
+	case *object.Boolean:
+		tok.Type, tok.Literal = lexer.FALSE, "false"
+		if obj.Value {
+			tok.Type, tok.Literal = lexer.TRUE, "true"
+		}
+		return &syntaxtree.BooleanLiteral{Token: tok, Value: obj.Value}, nil
+
+	// String objects
+	case *object.String:
+		tok.Type, tok.Literal = lexer.STRING, obj.Value
+		return &syntaxtree.StringLiteral{Token: tok, Value: obj.Value}, nil
+
+	// Null objects
+	case *object.Null:
+		tok.Type, tok.Literal = lexer.NULL, "null"
+		return &syntaxtree.NullLiteral{Token: tok}, nil
+
+	default:
+		return nil, locateError(object.NewErrorOfKind(object.MACRO_ERROR, "cannot unquote %s", obj.Type()), tok)
+	}
+}
+
+// A method of Interpreter that renames the names bound within a quoted node (by let statements,
+// function declarations, parameters, catch clauses and match patterns) throughout the node to
+// unique names. The generated names cannot be written in source code and do not capture any name.
+func (in *Interpreter) renameBindings(node syntaxtree.Node) syntaxtree.Node {
+	// Collect the bound names and generate a unique name for each
+	renamed := make(map[string]string)
+	syntaxtree.Modify(node, func(node syntaxtree.Node) syntaxtree.Node {
+		for _, ident := range boundNames(node) {
+			if _, ok := renamed[ident.Value]; !ok {
+				in.gensyms++
+				renamed[ident.Value] = fmt.Sprintf("%s#%d", ident.Value, in.gensyms)
+			}
+		}
+
+		return node
+	})
+
+	// Check if the node binds any names
+	if len(renamed) == 0 {
+		return node
+	}
+
+	// Replace the identifiers of the bound names
+	return syntaxtree.Modify(node, func(node syntaxtree.Node) syntaxtree.Node {
+		if ident, ok := node.(*syntaxtree.Identifier); ok {
+			if name, ok := renamed[ident.Value]; ok {
+				return &syntaxtree.Identifier{Token: ident.Token, Value: name}
+			}
+		}
+
+		return node
+	})
+}
+
+// A function that returns the identifiers bound by a syntax tree node (not including the names bound by its children)
+func boundNames(node syntaxtree.Node) []*syntaxtree.Identifier {
+	// Check the type of the node
+	switch node := node.(type) {
+
+	// Let Statement Node
+	case *syntaxtree.LetStatement:
+		return node.Names()
+
+	// Function Statement Node
+	case *syntaxtree.FunctionStatement:
+		return []*syntaxtree.Identifier{node.Name}
+
+	// Function Literal Node
+	case *syntaxtree.FunctionLiteral:
+		return node.Parameters
+
+	// Try Expression Node
+	case *syntaxtree.TryExpression:
+		if node.Parameter != nil {
+			return []*syntaxtree.Identifier{node.Parameter}
+		}
+
+	// Match Expression Node
+	case *syntaxtree.MatchExpression:
+		names := []*syntaxtree.Identifier{}
+		for _, arm := range node.Arms {
+			names = append(names, arm.Names()...)
+		}
+
+		return names
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package evaluator
+
+import (
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// A method of Interpreter that evaluates a match expression given a MatchExpression syntax
+// tree node. The arms are tried in order and the body of the first arm whose pattern matches
+// the subject (and whose guard is truthy) is evaluated in the scope of the pattern's bindings.
+func (in *Interpreter) evalMatchExpression(me *syntaxtree.MatchExpression, env *object.Environment) object.Object {
+	// Evaluate the subject of the match
+	subject := in.eval(me.Subject, env)
+	// Check if evaluated subject is an error
+	if isError(subject) {
+		// Return the error
+		return subject
+	}
+
+	// Iterate over the arms of the match
+	for _, arm := range me.Arms {
+		// Create a new scope for the bindings of the arm
+		armEnv := object.NewEnclosedEnvironment(env)
+
+		// Match the pattern of the arm against the subject
+		matched, err := in.matchPattern(arm.Pattern, subject, armEnv)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		// Check the guard of the arm
+		if arm.Guard != nil {
+			guard := in.eval(arm.Guard, armEnv)
+			// Check if evaluated guard is an error
+			if isError(guard) {
+				// Return the error
+				return guard
+			}
+
+			// Skip the arm if the guard is not truthy
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+
+		// Evaluate the body of the arm in the scope of its bindings
+		result := in.evalBlockStatement(arm.Body, armEnv)
+		// Return null for empty blocks
+		if result == nil {
+			return NULL
+		}
+
+		return result
+	}
+
+	// Return Error if no arm matched the subject
+	return locateError(object.NewErrorOfKind(object.MATCH_ERROR, "no match arm matched value: %s", subject.Inspect()), me.Token)
+}
+
+// A method of Interpreter that matches a pattern against a value and binds the names of the
+// pattern in the given environment. Returns whether the pattern matched and an Error object
+// if the matching failed (such as when a rest element cannot be allocated).
+func (in *Interpreter) matchPattern(pattern syntaxtree.Pattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	// Check the type of the pattern
+	switch pattern := pattern.(type) {
+
+	// Wildcard Pattern
+	case *syntaxtree.WildcardPattern:
+		return true, nil
+
+	// Binding Pattern
+	case *syntaxtree.BindingPattern:
+		// Bind the value to the name
+		if result := env.Set(pattern.Name.Value, value); isError(result) {
+			return false, locateError(result, pattern.Name.Token)
+		}
+
+		return true, nil
+
+	// Literal Pattern
+	case *syntaxtree.LiteralPattern:
+		// Evaluate the literal
+		literal := in.eval(pattern.Value, env)
+		// Check if evaluated literal is an error
+		if isError(literal) {
+			// Return the error
+			return false, literal
+		}
+
+		return object.Equal(literal, value), nil
+
+	// List Pattern
+	case *syntaxtree.ListPattern:
+		return in.matchListPattern(pattern, value, env)
+
+	// Map Pattern
+	case *syntaxtree.MapPattern:
+		return in.matchMapPattern(pattern, value, env)
+
+	default:
+		return false, nil
+	}
+}
+
+// A method of Interpreter that matches a list pattern against a value
+func (in *Interpreter) matchListPattern(pattern *syntaxtree.ListPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	// Check that the value is a List
+	list, ok := value.(*object.List)
+	if !ok {
+		return false, nil
+	}
+
+	// Check the number of elements of the List
+	count := len(pattern.Elements)
+	if len(list.Elements) < count || (!pattern.HasRest && len(list.Elements) != count) {
+		return false, nil
+	}
+
+	// Match the element patterns against the elements
+	for idx, element := range pattern.Elements {
+		if matched, err := in.matchPattern(element, list.Elements[idx], env); !matched || err != nil {
+			return false, err
+		}
+	}
+
+	// Bind the remaining elements to the rest element
+	if pattern.Rest != nil {
+		rest := in.allocate(&object.List{Elements: append([]object.Object{}, list.Elements[count:]...)})
+		// Check if the allocation failed
+		if isError(rest) {
+			return false, locateError(rest, pattern.Rest.Token)
+		}
+
+		if result := env.Set(pattern.Rest.Value, rest); isError(result) {
+			return false, locateError(result, pattern.Rest.Token)
+		}
+	}
+
+	return true, nil
+}
+
+// A method of Interpreter that matches a map pattern against a value
+func (in *Interpreter) matchMapPattern(pattern *syntaxtree.MapPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	// Check that the value is a Map
+	mapObject, ok := value.(*object.Map)
+	if !ok {
+		return false, nil
+	}
+
+	// Match the value patterns against the values of the keys
+	for idx, keyNode := range pattern.Keys {
+		// Evaluate the key
+		key := in.eval(keyNode, env)
+		// Check if evaluated key is an error
+		if isError(key) {
+			// Return the error
+			return false, key
+		}
+
+		// Retrieve the pair of the key
+		hashed, ok := object.HashKeyOf(key)
+		if !ok {
+			return false, nil
+		}
+		pair, ok := mapObject.Pairs[hashed]
+		if !ok {
+			return false, nil
+		}
+
+		// Match the value pattern against the value
+		if matched, err := in.matchPattern(pattern.Values[idx], pair.Value, env); !matched || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// A method of Interpreter that destructures a value into the names of the pattern of a let
+// statement and binds them in the given environment (as constants if constant is set).
+// Returns an Error object if the value does not have the shape of the pattern.
+func (in *Interpreter) destructure(pattern syntaxtree.Pattern, value object.Object, env *object.Environment, constant bool) object.Object {
+	// Check the type of the pattern
+	switch pattern := pattern.(type) {
+
+	// Binding Pattern
+	case *syntaxtree.BindingPattern:
+		// Bind the value to the name
+		var result object.Object
+		if constant {
+			result = env.SetConst(pattern.Name.Value, value)
+		} else {
+			result = env.Set(pattern.Name.Value, value)
+		}
+
+		// Check if the binding failed (the name is a constant)
+		if isError(result) {
+			return locateError(result, pattern.Name.Token)
+		}
+
+	// Default Pattern (the destructured element or key is present)
+	case *syntaxtree.DefaultPattern:
+		return in.destructure(pattern.Pattern, value, env, constant)
+
+	// List Pattern
+	case *syntaxtree.ListPattern:
+		return in.destructureList(pattern, value, env, constant)
+
+	// Map Pattern
+	case *syntaxtree.MapPattern:
+		return in.destructureMap(pattern, value, env, constant)
+	}
+
+	return nil
+}
+
+// A method of Interpreter that destructures a value into the names of a list pattern
+func (in *Interpreter) destructureList(pattern *syntaxtree.ListPattern, value object.Object, env *object.Environment, constant bool) object.Object {
+	// Check that the value is a List
+	list, ok := value.(*object.List)
+	if !ok {
+		return locateError(object.NewErrorOfKind(object.TYPE_ERROR, "cannot destructure %s as a list", value.Type()), pattern.Token)
+	}
+
+	// Check that the List does not have more elements than the pattern
+	count := len(pattern.Elements)
+	if !pattern.HasRest && len(list.Elements) > count {
+		return locateError(object.NewErrorOfKind(object.MATCH_ERROR, "too many elements to destructure: expected %d, got %d", count, len(list.Elements)), pattern.Token)
+	}
+
+	// Iterate over the element patterns
+	for idx, element := range pattern.Elements {
+		var result object.Object
+		if idx < len(list.Elements) {
+			// Destructure the element
+			result = in.destructure(element, list.Elements[idx], env, constant)
+		} else {
+			// Destructure the default value of the missing element
+			missing := object.NewErrorOfKind(object.MATCH_ERROR, "not enough elements to destructure: expected %d, got %d", count, len(list.Elements))
+			result = in.destructureDefault(element, env, constant, locateError(missing, pattern.Token))
+		}
+
+		// Check if the element could not be destructured
+		if isError(result) {
+			return result
+		}
+	}
+
+	// Bind the remaining elements to the rest element
+	if pattern.Rest != nil {
+		remaining := []object.Object{}
+		if len(list.Elements) > count {
+			remaining = append(remaining, list.Elements[count:]...)
+		}
+
+		// Account for the allocation of the remaining elements
+		rest := in.allocate(&object.List{Elements: remaining})
+		if isError(rest) {
+			return locateError(rest, pattern.Rest.Token)
+		}
+
+		return in.destructure(&syntaxtree.BindingPattern{Name: pattern.Rest}, rest, env, constant)
+	}
+
+	return nil
+}
+
+// A method of Interpreter that destructures a value into the names of a map pattern
+func (in *Interpreter) destructureMap(pattern *syntaxtree.MapPattern, value object.Object, env *object.Environment, constant bool) object.Object {
+	// Check that the value is a Map
+	mapObject, ok := value.(*object.Map)
+	if !ok {
+		return locateError(object.NewErrorOfKind(object.TYPE_ERROR, "cannot destructure %s as a map", value.Type()), pattern.Token)
+	}
+
+	// Iterate over the keys of the pattern
+	for idx, keyNode := range pattern.Keys {
+		// Evaluate the key
+		key := in.eval(keyNode, env)
+		// Check if evaluated key is an error
+		if isError(key) {
+			// Return the error
+			return key
+		}
+
+		// Retrieve the pair of the key (the keys of destructuring patterns are strings)
+		var result object.Object
+		if pair, ok := mapObject.Pairs[key.(object.Hashable).HashKey()]; ok {
+			// Destructure the value of the key
+			result = in.destructure(pattern.Values[idx], pair.Value, env, constant)
+		} else {
+			// Destructure the default value of the missing key
+			missing := object.NewErrorOfKind(object.MATCH_ERROR, "key not found: %s", key.Inspect())
+			result = in.destructureDefault(pattern.Values[idx], env, constant, locateError(missing, pattern.Token))
+		}
+
+		// Check if the value could not be destructured
+		if isError(result) {
+			return result
+		}
+	}
+
+	return nil
+}
+
+// A method of Interpreter that destructures the default value of a missing element or key
+// given the pattern of the element or key. The given error is returned if it has no default.
+func (in *Interpreter) destructureDefault(pattern syntaxtree.Pattern, env *object.Environment, constant bool, missing object.Object) object.Object {
+	// Check if the pattern has a default value
+	defaulted, ok := pattern.(*syntaxtree.DefaultPattern)
+	if !ok {
+		return missing
+	}
+
+	// Evaluate the default value
+	value := in.eval(defaulted.Default, env)
+	// Check if evaluated value is an error
+	if isError(value) {
+		// Return the error
+		return value
+	}
+
+	// Destructure the default value
+	return in.destructure(defaulted.Pattern, value, env, constant)
+}
@@ -0,0 +1,142 @@
+package evaluator
+
+import (
+	"sync/atomic"
+
+	"github.com/manishmeganathan/tunalang/object"
+)
+
+const (
+	// Represents the estimated size of an object header in bytes
+	objectHeaderSize = 16
+	// Represents the estimated size of a List element slot in bytes
+	listElementSize = 16
+	// Represents the estimated size of a Map key-value pair in bytes
+	mapPairSize = 64
+	// Represents the maximum size of a repeated value in bytes, which applies without an allocation limit
+	maxRepeatedSize = 1 << 30
+)
+
+// A structure that represents an allocation meter that estimates the memory
+// allocated by the List, Map, String, Instance and EnumValue objects of a program. The allocation
+// count is updated atomically because built-in functions allocate concurrently.
+type AllocationMeter struct {
+	// Represents the maximum number of bytes that may be allocated (0 is unlimited)
+	Limit int64
+
+	// Represents the estimated number of bytes allocated so far
+	Allocated int64
+}
+
+// A function that sets the allocation limit (in bytes) of each evaluation of the
+// default Interpreter and resets its allocation count. A limit of 0 disables the limit.
+func SetAllocationLimit(limit int64) {
+	defaultInterpreter.SetAllocationLimit(limit)
+}
+
+// A function that returns the estimated number of bytes allocated by the latest evaluation of the default Interpreter
+func AllocatedBytes() int64 {
+	return defaultInterpreter.AllocatedBytes()
+}
+
+// A method of Interpreter that sets the allocation limit (in bytes) of each of its evaluations
+// and resets its allocation count. A limit of 0 disables the limit. An evaluation that is
+// in progress keeps its limit, the new limit applies from the next top-level evaluation.
+func (in *Interpreter) SetAllocationLimit(limit int64) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.Limits.MaxAllocation = limit
+	// Reset the allocation meter if no evaluation is in progress
+	if in.active == 0 {
+		in.meter = &AllocationMeter{Limit: limit}
+	}
+}
+
+// A method of Interpreter that returns the estimated number of bytes
+// allocated by its latest (or current) top-level evaluation
+func (in *Interpreter) AllocatedBytes() int64 {
+	in.mu.Lock()
+	meter := in.meter
+	in.mu.Unlock()
+
+	return atomic.LoadInt64(&meter.Allocated)
+}
+
+// A method of AllocationMeter that accounts for a newly created object
+// and returns it or an out-of-memory Error if the limit is exceeded
+func (m *AllocationMeter) Allocate(obj object.Object) object.Object {
+	// Add the estimated size of the object to the allocation count
+	allocated := atomic.AddInt64(&m.Allocated, SizeOf(obj))
+
+	// Check if the allocation limit has been exceeded
+	if m.Limit > 0 && allocated > m.Limit {
+		// Return an out-of-memory Error
+		return object.NewErrorOfKind(object.MEMORY_ERROR, "out of memory: allocation limit of %d bytes exceeded", m.Limit)
+	}
+
+	// Return the object
+	return obj
+}
+
+// A function that returns the estimated size of an object in bytes. The size is shallow,
+// elements of collections are not included because they are accounted when created.
+func SizeOf(obj object.Object) int64 {
+	// Check the type of object
+	switch obj := obj.(type) {
+
+	// String objects are sized by their bytes
+	case *object.String:
+		return objectHeaderSize + int64(len(obj.Value))
+
+	// List objects are sized by their element slots
+	case *object.List:
+		return objectHeaderSize + int64(len(obj.Elements))*listElementSize
+
+	// Map objects are sized by their key-value pairs
+	case *object.Map:
+		return objectHeaderSize + int64(len(obj.Pairs))*mapPairSize
+
+	// Instance objects are sized by their fields
+	case *object.Instance:
+		return objectHeaderSize + int64(len(obj.Fields))*mapPairSize
+
+	// EnumValue objects are sized by their payload
+	case *object.EnumValue:
+		return objectHeaderSize + int64(len(obj.Payload))*listElementSize
+
+	// All other objects are not metered
+	default:
+		return 0
+	}
+}
+
+// A method of AllocationMeter that returns an out-of-memory Error if allocating
+// the given number of bytes would exceed the limit, without accounting for them.
+// It is used to reject large allocations before the object is created.
+func (m *AllocationMeter) Reserve(size int64) *object.Error {
+	// Check if the allocation limit would be exceeded
+	if m.Limit > 0 && size > m.Limit-atomic.LoadInt64(&m.Allocated) {
+		// Return an out-of-memory Error
+		return object.NewErrorOfKind(object.MEMORY_ERROR, "out of memory: allocation limit of %d bytes exceeded", m.Limit)
+	}
+
+	return nil
+}
+
+// A method of Interpreter that checks whether the given number of bytes can be
+// allocated on its allocation meter and returns an out-of-memory Error otherwise
+func (in *Interpreter) reserve(size int64) object.Object {
+	// Check the allocation meter
+	if err := in.meter.Reserve(size); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// A method of Interpreter that accounts for a newly created object on its
+// allocation meter and returns it or an out-of-memory Error
+func (in *Interpreter) allocate(obj object.Object) object.Object {
+	return in.meter.Allocate(obj)
+}
@@ -0,0 +1,176 @@
+package evaluator
+
+import (
+	"strings"
+
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// A function that generates and returns the default method tables of the object types
+// for a given Interpreter. Methods that are also built-in functions (such as len and
+// push) call the given default built-in function with the receiver as first argument.
+func newMethods(in *Interpreter, builtins map[string]*object.Builtin) map[object.ObjectType]map[string]object.MethodFunction {
+	return map[object.ObjectType]map[string]object.MethodFunction{
+		object.LIST_OBJ: {
+			"len":   builtinMethod(builtins["len"], 0),
+			"first": builtinMethod(builtins["first"], 0),
+			"last":  builtinMethod(builtins["last"], 0),
+			"tail":  builtinMethod(builtins["tail"], 0),
+			"push":  builtinMethod(builtins["push"], 1),
+			"join": func(receiver object.Object, args ...object.Object) object.Object {
+
+				if len(args) != 1 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				separator, ok := args[0].(*object.String)
+				if !ok {
+					return object.NewErrorOfKind(object.TYPE_ERROR, "argument to `join` must be STRING, got %s",
+						args[0].Type())
+				}
+
+				elements := []string{}
+				for _, element := range receiver.(*object.List).Elements {
+					elements = append(elements, object.Str(element))
+				}
+
+				return in.allocate(&object.String{Value: strings.Join(elements, separator.Value)})
+			},
+		},
+		object.STRING_OBJ: {
+			"len":   builtinMethod(builtins["len"], 0),
+			"upper": stringMethod(in, strings.ToUpper),
+			"lower": stringMethod(in, strings.ToLower),
+			"trim":  stringMethod(in, strings.TrimSpace),
+			"split": func(receiver object.Object, args ...object.Object) object.Object {
+
+				if len(args) != 1 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				separator, ok := args[0].(*object.String)
+				if !ok {
+					return object.NewErrorOfKind(object.TYPE_ERROR, "argument to `split` must be STRING, got %s",
+						args[0].Type())
+				}
+
+				parts := strings.Split(receiver.(*object.String).Value, separator.Value)
+				elements := make([]object.Object, len(parts))
+				for idx, part := range parts {
+					if elements[idx] = in.allocate(&object.String{Value: part}); isError(elements[idx]) {
+						return elements[idx]
+					}
+				}
+
+				return in.allocate(&object.List{Elements: elements})
+			},
+		},
+		object.MAP_OBJ: {
+			"len": func(receiver object.Object, args ...object.Object) object.Object {
+
+				if len(args) != 0 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=0",
+						len(args))
+				}
+
+				return &object.Integer{Value: int64(len(receiver.(*object.Map).Pairs))}
+			},
+			"has": func(receiver object.Object, args ...object.Object) object.Object {
+
+				if len(args) != 1 {
+					return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				key, ok := object.HashKeyOf(args[0])
+				if !ok {
+					return object.NewErrorOfKind(object.TYPE_ERROR, "unusable as hash key: %s", args[0].Type())
+				}
+
+				_, exists := receiver.(*object.Map).Pairs[key]
+				return getNativeBoolean(exists)
+			},
+		},
+	}
+}
+
+// A function that creates a method from a built-in function that is called with
+// the receiver as its first argument, given the number of the other arguments
+func builtinMethod(builtin *object.Builtin, arity int) object.MethodFunction {
+	return func(receiver object.Object, args ...object.Object) object.Object {
+		// Check the number of arguments (without the receiver)
+		if len(args) != arity {
+			return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=%d", len(args), arity)
+		}
+
+		// Call the built-in function with the receiver
+		return builtin.Fn(append([]object.Object{receiver}, args...)...)
+	}
+}
+
+// A function that creates a String method without arguments from a function that transforms a string
+func stringMethod(in *Interpreter, transform func(string) string) object.MethodFunction {
+	return func(receiver object.Object, args ...object.Object) object.Object {
+		// Check the number of arguments
+		if len(args) != 0 {
+			return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of arguments. got=%d, want=0", len(args))
+		}
+
+		// Return the transformed String after accounting for its allocation
+		return in.allocate(&object.String{Value: transform(receiver.(*object.String).Value)})
+	}
+}
+
+// A method of Interpreter that evaluates a member access on an object given the name of the
+// member. Members of modules, instances and enums are looked up first, then the method table
+// of the type of the object and finally the String keys of a Map (null if the key is missing).
+func (in *Interpreter) evalMemberExpression(obj object.Object, property *syntaxtree.Identifier) object.Object {
+	// Check the type of the accessed object
+	switch obj := obj.(type) {
+
+	// Retrieve an exported binding of a Module
+	case *object.Module:
+		value, ok := obj.Exports[property.Value]
+		if !ok {
+			return object.NewErrorOfKind(object.NAME_ERROR, "module %s has no export: %s", obj.Name, property.Value)
+		}
+
+		return value
+
+	// Retrieve a field or method of an Instance
+	case *object.Instance:
+		return instanceMember(obj, property.Value)
+
+	// Retrieve a variant of an Enum
+	case *object.Enum:
+		return enumMember(obj, property.Value)
+
+	// Retrieve a payload field of an EnumValue
+	case *object.EnumValue:
+		return enumValueMember(obj, property.Value)
+	}
+
+	// Retrieve the method from the method table of the type
+	if method, ok := in.methods[obj.Type()][property.Value]; ok {
+		// Bind the method to the object
+		return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+			return method(obj, args...)
+		}}
+	}
+
+	// Retrieve the value of the String key from a Map
+	if mapObject, ok := obj.(*object.Map); ok {
+		return evalMapIndexExpression(mapObject, &object.String{Value: property.Value})
+	}
+
+	// Check if the type has methods
+	if len(in.methods[obj.Type()]) > 0 {
+		return object.NewErrorOfKind(object.NAME_ERROR, "%s has no method: %s", obj.Type(), property.Value)
+	}
+
+	// Return Error
+	return object.NewErrorOfKind(object.TYPE_ERROR, "member access not supported: %s", obj.Type())
+}
@@ -0,0 +1,192 @@
+package evaluator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/manishmeganathan/tunalang/lexer"
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/parser"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// Represents the file extension of Tuna modules, which may be omitted from import paths
+const moduleExtension = ".tuna"
+
+// A method of Interpreter that evaluates an import statement given an ImportStatement
+// syntax tree node. The imported module is bound to the name of the statement.
+func (in *Interpreter) evalImportStatement(node *syntaxtree.ImportStatement, env *object.Environment) object.Object {
+	// Import the module
+	module := in.importModule(node.Path.Value)
+	// Check if the import failed
+	if errObj, ok := module.(*object.Error); ok {
+		// Locate errors raised while resolving the module at the import statement
+		if errObj.Line == 0 {
+			return locateError(errObj, node.Token)
+		}
+
+		// Add the import to the stack of errors raised within the module
+		errObj.Stack = append(errObj.Stack, object.Frame{
+			Function: "<module " + node.Path.Value + ">",
+			Line:     node.Token.Line,
+			Column:   node.Token.Column,
+		})
+
+		return errObj
+	}
+
+	// Bind the module to the name
+	if result := env.Set(node.Name.Value, module); isError(result) {
+		return locateError(result, node.Name.Token)
+	}
+
+	return nil
+}
+
+// A method of Interpreter that imports a module given its import path. A module is
+// evaluated once in its own environment and cached, later imports return the cached
+// Module object. An Error is returned if the module cannot be found, parsed or
+// evaluated or if it is already being loaded (an import cycle).
+func (in *Interpreter) importModule(name string) object.Object {
+	// Resolve the path of the module file
+	path, err := in.resolveModule(name)
+	if err != nil {
+		return err
+	}
+
+	// Return the cached module if it has been evaluated
+	if module, ok := in.modules[path]; ok {
+		return module
+	}
+
+	// Check if the module is already being loaded
+	for idx, loading := range in.state.importing {
+		if loading == path {
+			cycle := append(append([]string{}, in.state.importing[idx:]...), path)
+			return object.NewErrorOfKind(object.IMPORT_ERROR, "import cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	// Read the module file
+	source, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return object.NewErrorOfKind(object.IMPORT_ERROR, "could not read module %s", name)
+	}
+
+	// Parse the module into a Program
+	par := parser.NewParser(lexer.NewLexer(string(source)))
+	program := par.ParseProgram()
+	// Check for parser errors (their messages are not reported because they may contain the content of the file)
+	if len(par.Errors) != 0 {
+		return object.NewErrorOfKind(object.IMPORT_ERROR, "could not parse module %s: %d syntax errors", name, len(par.Errors))
+	}
+
+	// Evaluate the module in its own environment while it is marked as being loaded
+	env := object.NewEnvironment()
+	importing := in.state.importing
+	in.state.importing = append(append([]string{}, importing...), path)
+	result := in.evalProgram(program, env)
+	in.state.importing = importing
+
+	// Check if the evaluation of the module failed
+	if isError(result) {
+		return result
+	}
+
+	// Collect the exported bindings of the module
+	module := &object.Module{Name: name, Path: path, Exports: make(map[string]object.Object)}
+	for _, statement := range program.Statements {
+		if export, ok := statement.(*syntaxtree.ExportStatement); ok {
+			for _, name := range export.Declaration.Names() {
+				value, _ := env.Get(name.Value)
+				module.Exports[name.Value] = value
+			}
+		}
+	}
+
+	// Cache and return the module
+	in.modules[path] = module
+	return module
+}
+
+// A method of Interpreter that resolves the import path of a module into the absolute path of its
+// file. Module imports are disabled if the interpreter has no module paths. Import paths must be
+// relative, they are searched in the directory of the importing module (if the import is within a
+// module) and then in the module paths, and must resolve to a file within one of the module paths.
+func (in *Interpreter) resolveModule(name string) (string, object.Object) {
+	// Check if module imports are enabled
+	if len(in.ModulePaths) == 0 {
+		return "", object.NewErrorOfKind(object.IMPORT_ERROR, "module imports are disabled")
+	}
+
+	// Check that the import path is relative
+	if filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", object.NewErrorOfKind(object.IMPORT_ERROR, "module path must be relative: %s", name)
+	}
+
+	// Add the module extension if the path does not have one
+	file := name
+	if filepath.Ext(file) == "" {
+		file += moduleExtension
+	}
+
+	// Resolve the module paths into the roots that modules must be within
+	roots := make([]string, 0, len(in.ModulePaths))
+	for _, dir := range in.ModulePaths {
+		if root, err := resolvePath(dir); err == nil {
+			roots = append(roots, root)
+		}
+	}
+
+	// Collect the directories to search
+	var dirs []string
+	if count := len(in.state.importing); count > 0 {
+		dirs = append(dirs, filepath.Dir(in.state.importing[count-1]))
+	}
+	dirs = append(dirs, roots...)
+
+	// Search the directories for the module file
+	for _, dir := range dirs {
+		// Check that the path is within a module path (before the file is accessed)
+		path := filepath.Join(dir, file)
+		if !withinRoots(path, roots) {
+			return "", object.NewErrorOfKind(object.IMPORT_ERROR, "module path is outside the module paths: %s", name)
+		}
+
+		// Resolve the symbolic links of the path and check that it is still within a module path
+		path, err := filepath.EvalSymlinks(path)
+		if err != nil || !withinRoots(path, roots) {
+			continue
+		}
+
+		// Check that the file is a regular file
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path, nil
+		}
+	}
+
+	return "", object.NewErrorOfKind(object.IMPORT_ERROR, "module not found: %s", name)
+}
+
+// A function that resolves a path into an absolute path without symbolic links
+func resolvePath(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(path)
+}
+
+// A function that returns whether a resolved path is within one of the given resolved directories
+func withinRoots(path string, roots []string) bool {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
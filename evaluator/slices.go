@@ -0,0 +1,162 @@
+package evaluator
+
+import (
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// A method of Interpreter that evaluates a slice expression given a SliceExpression syntax tree
+// node. Slicing a List or a String returns a copy of the selected elements or characters.
+func (in *Interpreter) evalSliceExpression(node *syntaxtree.SliceExpression, env *object.Environment) object.Object {
+	// Evaluate the left expression
+	left := in.evalChain(node.Left, env)
+	// Check if evaluated value is an error or a short-circuited chain
+	if isError(left) || left == shortCircuited {
+		// Return the error or the short-circuited chain
+		return left
+	}
+
+	// Check if the slice is optional and the value is null
+	if node.Optional && left == NULL {
+		// Short-circuit the chain without evaluating the bounds
+		return shortCircuited
+	}
+
+	// Evaluate the bounds of the slice
+	bounds := make([]*object.Integer, 3)
+	for idx, exp := range []syntaxtree.Expression{node.Start, node.End, node.Step} {
+		// Skip omitted bounds
+		if exp == nil {
+			continue
+		}
+
+		// Evaluate the bound expression
+		bound := in.eval(exp, env)
+		// Check if evaluated value is an error
+		if isError(bound) {
+			// Return the error
+			return bound
+		}
+
+		// Assert that the bound is an Integer
+		integer, ok := bound.(*object.Integer)
+		if !ok {
+			// Return Error
+			return object.NewErrorOfKind(object.TYPE_ERROR, "slice indices must be integers. got=%s", bound.Type())
+		}
+
+		bounds[idx] = integer
+	}
+
+	// Check the type of the sliced object
+	switch left := left.(type) {
+
+	// Slice the elements of a List
+	case *object.List:
+		// Resolve the positions of the selected elements
+		positions, err := slicePositions(len(left.Elements), bounds[0], bounds[1], bounds[2])
+		if err != nil {
+			return err
+		}
+
+		// Copy the selected elements
+		elements := make([]object.Object, 0, len(positions))
+		for _, position := range positions {
+			elements = append(elements, left.Elements[position])
+		}
+
+		// Return the List Object after accounting for its allocation
+		return in.allocate(&object.List{Elements: elements})
+
+	// Slice the characters of a String
+	case *object.String:
+		// Retrieve the characters of the string
+		chars := []rune(left.Value)
+
+		// Resolve the positions of the selected characters
+		positions, err := slicePositions(len(chars), bounds[0], bounds[1], bounds[2])
+		if err != nil {
+			return err
+		}
+
+		// Copy the selected characters
+		selected := make([]rune, 0, len(positions))
+		for _, position := range positions {
+			selected = append(selected, chars[position])
+		}
+
+		// Return the String Object after accounting for its allocation
+		return in.allocate(&object.String{Value: string(selected)})
+
+	default:
+		// Return Error
+		return object.NewErrorOfKind(object.TYPE_ERROR, "slice operator not supported: %s", left.Type())
+	}
+}
+
+// A function that resolves the start, end and step of a slice (nil if omitted) against a
+// length and returns the selected positions. Negative bounds count from the end and bounds
+// that are out of range are clamped. A negative step selects the positions in reverse.
+func slicePositions(length int, start, end, step *object.Integer) ([]int, object.Object) {
+	// Resolve the step of the slice (defaults to 1)
+	stride := 1
+	if step != nil {
+		// Check that the step is not zero
+		if step.Value == 0 {
+			// Return Error
+			return nil, object.NewErrorOfKind(object.ARGUMENT_ERROR, "slice step cannot be zero")
+		}
+
+		stride = clampBound(step.Value, -length-1, length+1)
+	}
+
+	// Determine the defaults and limits of the bounds for the direction of the step
+	first, last, lower, upper := 0, length, 0, length
+	if stride < 0 {
+		first, last, lower, upper = length-1, -1, -1, length-1
+	}
+
+	// Resolve the start and end of the slice
+	from := resolveBound(start, first, length, lower, upper)
+	to := resolveBound(end, last, length, lower, upper)
+
+	// Collect the selected positions
+	var positions []int
+	for pos := from; (stride > 0 && pos < to) || (stride < 0 && pos > to); pos += stride {
+		positions = append(positions, pos)
+	}
+
+	// Return the selected positions
+	return positions, nil
+}
+
+// A function that resolves a bound of a slice (nil if omitted) given its default value and the
+// length of the sliced object. Negative bounds count from the end and are clamped to the limits.
+func resolveBound(bound *object.Integer, fallback, length, lower, upper int) int {
+	// Return the default if the bound is omitted
+	if bound == nil {
+		return fallback
+	}
+
+	// Count negative bounds from the end
+	value := bound.Value
+	if value < 0 {
+		value += int64(length)
+	}
+
+	// Clamp the bound to the limits
+	return clampBound(value, lower, upper)
+}
+
+// A function that clamps an integer value between a lower and upper limit
+func clampBound(value int64, lower, upper int) int {
+	// Check the value against the limits
+	switch {
+	case value < int64(lower):
+		return lower
+	case value > int64(upper):
+		return upper
+	default:
+		return int(value)
+	}
+}
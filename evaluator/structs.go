@@ -0,0 +1,116 @@
+package evaluator
+
+import (
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// Represents the name that the receiver of a method is bound to
+const selfName = "self"
+
+// A function that evaluates a struct declaration given a StructStatement syntax
+// tree node. The Struct object is bound to the name of the declaration and its
+// methods are closures over the environment of the declaration.
+func evalStructStatement(node *syntaxtree.StructStatement, env *object.Environment) object.Object {
+	// Create the Struct object
+	structObject := &object.Struct{Name: node.Name.Value, Methods: make(map[string]*object.Function)}
+
+	// Collect the names of the fields
+	for _, field := range node.Fields {
+		structObject.Fields = append(structObject.Fields, field.Value)
+	}
+
+	// Create the functions of the methods
+	for _, method := range node.Methods {
+		structObject.Methods[method.Name.Value] = &object.Function{Name: method.Name.Value, Parameters: method.Function.Parameters, Body: method.Function.Body, Env: env}
+	}
+
+	// Bind the struct to its name
+	if result := env.Set(node.Name.Value, structObject); isError(result) {
+		return locateError(result, node.Name.Token)
+	}
+
+	return nil
+}
+
+// A method of Interpreter that constructs an Instance of a Struct from the arguments of a call
+// to the Struct. The arguments are the values of the fields in the order of their declaration,
+// or a single Map of field names to values whose names are checked against the fields. A Struct
+// with one field is always constructed from its value, such that the value can be a Map.
+func (in *Interpreter) constructInstance(structObject *object.Struct, args []object.Object) object.Object {
+	// Create the instance
+	instance := &object.Instance{Struct: structObject, Fields: make(map[string]object.Object, len(structObject.Fields))}
+
+	// Check if the fields are given by their name
+	if fields, ok := singleMapArgument(args); ok && len(structObject.Fields) != 1 {
+		// Iterate over the named fields
+		for _, pair := range fields.Pairs {
+			// Check that the name is a String
+			name, ok := pair.Key.(*object.String)
+			if !ok {
+				return object.NewErrorOfKind(object.TYPE_ERROR, "field names must be strings. got=%s", pair.Key.Type())
+			}
+
+			// Check that the name is a field of the struct
+			if !structObject.HasField(name.Value) {
+				return object.NewErrorOfKind(object.NAME_ERROR, "unknown field for %s: %s", structObject.Name, name.Value)
+			}
+
+			instance.Fields[name.Value] = pair.Value
+		}
+
+		// Check that every field has a value
+		for _, field := range structObject.Fields {
+			if _, ok := instance.Fields[field]; !ok {
+				return object.NewErrorOfKind(object.ARGUMENT_ERROR, "missing field for %s: %s", structObject.Name, field)
+			}
+		}
+
+	} else {
+		// Check the number of fields
+		if len(args) != len(structObject.Fields) {
+			return object.NewErrorOfKind(object.ARGUMENT_ERROR, "wrong number of fields for %s. got=%d, want=%d", structObject.Name, len(args), len(structObject.Fields))
+		}
+
+		// Assign the fields in the order of declaration
+		for idx, field := range structObject.Fields {
+			instance.Fields[field] = args[idx]
+		}
+	}
+
+	// Return the Instance after accounting for its allocation
+	return in.allocate(instance)
+}
+
+// A function that returns the Map of a call with a single Map argument
+func singleMapArgument(args []object.Object) (*object.Map, bool) {
+	// Check the number of arguments
+	if len(args) != 1 {
+		return nil, false
+	}
+
+	// Check the type of the argument
+	mapObject, ok := args[0].(*object.Map)
+	return mapObject, ok
+}
+
+// A function that retrieves a member of an Instance given its name. Fields are retrieved
+// before methods, which are returned as functions with self bound to the Instance.
+func instanceMember(instance *object.Instance, name string) object.Object {
+	// Retrieve the field with the name
+	if value, ok := instance.Fields[name]; ok {
+		return value
+	}
+
+	// Retrieve the method with the name
+	if method, ok := instance.Struct.Methods[name]; ok {
+		// Bind the instance to self in the scope of the method
+		env := object.NewEnclosedEnvironment(method.Env)
+		env.Set(selfName, instance)
+
+		return &object.Function{Name: method.Name, Parameters: method.Parameters, Body: method.Body, Env: env}
+	}
+
+	// Return Error
+	return object.NewErrorOfKind(object.NAME_ERROR, "%s has no field or method: %s", instance.Struct.Name, name)
+}
@@ -13,13 +13,20 @@ type Lexer struct {
 
 	// Represents the current char
 	ch byte
+
+	// Represents the line and column of the current char
+	line   int
+	column int
+
+	// Represents the brace depth within each open interpolation of a string (the innermost last)
+	interpolations []int
 }
 
 // A constructor function that generates and
 // returns an initialised Lexer object
 func NewLexer(input string) *Lexer {
 	// Construct a lexer with the input
-	l := &Lexer{input: input}
+	l := &Lexer{input: input, line: 1}
 	// Read the first character of the input
 	// to initialise the lexer
 	l.ReadChar()
@@ -34,6 +41,8 @@ func (l *Lexer) NextToken() Token {
 
 	// Eat all whitespaces until next character
 	l.EatWhitespaces()
+	// Record the position of the token
+	line, column := l.line, l.column
 
 	// Check the value of the character read by the lexer
 	switch l.ch {
@@ -45,6 +54,12 @@ func (l *Lexer) NextToken() Token {
 			// Set the token value to '=='
 			tok = Token{Type: EQ, Literal: "=="}
 
+		} else if l.PeekChar() == '>' {
+			// Move lexer to the next character
+			l.ReadChar()
+			// Set the token value to '=>'
+			tok = Token{Type: FATARROW, Literal: "=>"}
+
 		} else {
 			// Set the token value to '='
 			tok = NewToken(ASSIGN, l.ch)
@@ -71,9 +86,60 @@ func (l *Lexer) NextToken() Token {
 	case '*':
 		tok = NewToken(ASTERISK, l.ch)
 	case '<':
-		tok = NewToken(LT, l.ch)
+		// Check if the next character is a '='
+		if l.PeekChar() == '=' {
+			// Move lexer to the next character
+			l.ReadChar()
+			// Set the token value to '<='
+			tok = Token{Type: LT_EQ, Literal: "<="}
+
+		} else {
+			// Set the token value to '<'
+			tok = NewToken(LT, l.ch)
+		}
 	case '>':
-		tok = NewToken(GT, l.ch)
+		// Check if the next character is a '='
+		if l.PeekChar() == '=' {
+			// Move lexer to the next character
+			l.ReadChar()
+			// Set the token value to '>='
+			tok = Token{Type: GT_EQ, Literal: ">="}
+
+		} else {
+			// Set the token value to '>'
+			tok = NewToken(GT, l.ch)
+		}
+	case '.':
+		// Check if the next characters are '..'
+		if l.PeekChar() == '.' && l.positionNext+1 < len(l.input) && l.input[l.positionNext+1] == '.' {
+			// Move lexer to the last character
+			l.ReadChar()
+			l.ReadChar()
+			// Set the token value to '...'
+			tok = Token{Type: ELLIPSIS, Literal: "..."}
+
+		} else {
+			// Set the token value to '.'
+			tok = NewToken(DOT, l.ch)
+		}
+	case '?':
+		// Check if the next character is a '?'
+		if l.PeekChar() == '?' {
+			// Move lexer to the next character
+			l.ReadChar()
+			// Set the token value to '??'
+			tok = Token{Type: COALESCE, Literal: "??"}
+
+		} else if l.PeekChar() == '.' {
+			// Move lexer to the next character
+			l.ReadChar()
+			// Set the token value to '?.'
+			tok = Token{Type: OPTDOT, Literal: "?."}
+
+		} else {
+			// Illegal Token
+			tok = NewToken(ILLEGAL, l.ch)
+		}
 	case ':':
 		tok = NewToken(COLON, l.ch)
 	case ';':
@@ -85,8 +151,23 @@ func (l *Lexer) NextToken() Token {
 	case ',':
 		tok = NewToken(COMMA, l.ch)
 	case '{':
+		// Track the depth of braces within an interpolation
+		if count := len(l.interpolations); count > 0 {
+			l.interpolations[count-1]++
+		}
 		tok = NewToken(LBRACE, l.ch)
 	case '}':
+		// Check if the brace closes an interpolation
+		if count := len(l.interpolations); count > 0 {
+			if l.interpolations[count-1] == 0 {
+				// Resume reading the string after the interpolation
+				l.interpolations = l.interpolations[:count-1]
+				tok = l.readStringToken(STRING_MIDDLE, STRING_TAIL)
+				break
+			}
+
+			l.interpolations[count-1]--
+		}
 		tok = NewToken(RBRACE, l.ch)
 	case '[':
 		tok = NewToken(LBRACK, l.ch)
@@ -94,8 +175,7 @@ func (l *Lexer) NextToken() Token {
 		tok = NewToken(RBRACK, l.ch)
 
 	case '"':
-		tok.Type = STRING
-		tok.Literal = l.ReadString()
+		tok = l.readStringToken(STRING_HEAD, STRING)
 	case 0:
 		// End of File
 		tok.Literal = ""
@@ -108,6 +188,8 @@ func (l *Lexer) NextToken() Token {
 			tok.Literal = l.ReadIdentifier()
 			// Get the mapping of the identifier literal to the type
 			tok.Type = LookUpIndentifier(tok.Literal)
+			// Set the position of the token
+			tok.Line, tok.Column = line, column
 			// Return the identifier token
 			return tok
 
@@ -116,6 +198,8 @@ func (l *Lexer) NextToken() Token {
 			tok.Literal = l.ReadNumber()
 			// Set the token type
 			tok.Type = INT
+			// Set the position of the token
+			tok.Line, tok.Column = line, column
 			// Return the numeric token
 			return tok
 
@@ -125,6 +209,8 @@ func (l *Lexer) NextToken() Token {
 		}
 	}
 
+	// Set the position of the token
+	tok.Line, tok.Column = line, column
 	// Read the next character from the lexer input
 	l.ReadChar()
 	// Return the lexed token
@@ -137,3 +223,20 @@ func isLetter(ch byte) bool {
 func isDigit(ch byte) bool {
 	return '0' <= ch && ch <= '9'
 }
+
+// A method of Lexer that reads the text of a string from the lexer input into a token. The token
+// has the interpolated type if the text ends at an interpolation, which is then opened, and the
+// terminated type if the text ends at the end of the string.
+func (l *Lexer) readStringToken(interpolated, terminated TokenType) Token {
+	// Read the text of the string
+	literal, interpolation := l.ReadString()
+
+	// Check if the text ends at an interpolation
+	if interpolation {
+		// Open the interpolation
+		l.interpolations = append(l.interpolations, 0)
+		return Token{Type: interpolated, Literal: literal}
+	}
+
+	return Token{Type: terminated, Literal: literal}
+}
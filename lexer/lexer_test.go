@@ -24,6 +24,10 @@ if (5 < 10) {
 
 10 == 10;
 10 != 9;
+10 <= 9 >= 8;
+1 in x;
+import "lib" as lib;
+export let y = lib.z;
 
 "foobar"
 "foo bar"
@@ -114,6 +118,29 @@ if (5 < 10) {
 		{NOT_EQ, "!="},
 		{INT, "9"},
 		{SEMICOLON, ";"},
+		{INT, "10"},
+		{LT_EQ, "<="},
+		{INT, "9"},
+		{GT_EQ, ">="},
+		{INT, "8"},
+		{SEMICOLON, ";"},
+		{INT, "1"},
+		{IN, "in"},
+		{IDENT, "x"},
+		{SEMICOLON, ";"},
+		{IMPORT, "import"},
+		{STRING, "lib"},
+		{AS, "as"},
+		{IDENT, "lib"},
+		{SEMICOLON, ";"},
+		{EXPORT, "export"},
+		{LET, "let"},
+		{IDENT, "y"},
+		{ASSIGN, "="},
+		{IDENT, "lib"},
+		{DOT, "."},
+		{IDENT, "z"},
+		{SEMICOLON, ";"},
 
 		{STRING, "foobar"},
 		{STRING, "foo bar"},
@@ -148,3 +175,225 @@ if (5 < 10) {
 		}
 	}
 }
+
+func TestInterpolatedStringTokens(t *testing.T) {
+	input := `"Hi ${name}, ${ {"k": "${x}"}["k"] }!" "${a}" "$5 {}" "\${a} is ${a}" "a\b\$"`
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{STRING_HEAD, "Hi "},
+		{IDENT, "name"},
+		{STRING_MIDDLE, ", "},
+		{LBRACE, "{"},
+		{STRING, "k"},
+		{COLON, ":"},
+		{STRING_HEAD, ""},
+		{IDENT, "x"},
+		{STRING_TAIL, ""},
+		{RBRACE, "}"},
+		{LBRACK, "["},
+		{STRING, "k"},
+		{RBRACK, "]"},
+		{STRING_TAIL, "!"},
+		{STRING_HEAD, ""},
+		{IDENT, "a"},
+		{STRING_TAIL, ""},
+		{STRING, "$5 {}"},
+		{STRING_HEAD, "${a} is "},
+		{IDENT, "a"},
+		{STRING_TAIL, ""},
+		{STRING, "a\\b$"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - invalid tokentype. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - invalid token literal. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	input := `let x = 5;
+let add = fn(a, b) {
+	a + b;
+};
+"str" == x`
+
+	tests := []struct {
+		expectedType   TokenType
+		expectedLine   int
+		expectedColumn int
+	}{
+		{LET, 1, 1},
+		{IDENT, 1, 5},
+		{ASSIGN, 1, 7},
+		{INT, 1, 9},
+		{SEMICOLON, 1, 10},
+		{LET, 2, 1},
+		{IDENT, 2, 5},
+		{ASSIGN, 2, 9},
+		{FUNCTION, 2, 11},
+		{LPAREN, 2, 13},
+		{IDENT, 2, 14},
+		{COMMA, 2, 15},
+		{IDENT, 2, 17},
+		{RPAREN, 2, 18},
+		{LBRACE, 2, 20},
+		{IDENT, 3, 2},
+		{PLUS, 3, 4},
+		{IDENT, 3, 6},
+		{SEMICOLON, 3, 7},
+		{RBRACE, 4, 1},
+		{SEMICOLON, 4, 2},
+		{STRING, 5, 1},
+		{EQ, 5, 7},
+		{IDENT, 5, 10},
+		{EOF, 5, 11},
+	}
+
+	l := NewLexer(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - invalid tokentype. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Line != tt.expectedLine || tok.Column != tt.expectedColumn {
+			t.Fatalf("tests[%d] - invalid token position. expected=%d:%d, got=%d:%d", i, tt.expectedLine, tt.expectedColumn, tok.Line, tok.Column)
+		}
+	}
+}
+
+func TestMatchTokens(t *testing.T) {
+	input := `match (xs) { [a, ...rest] => a, _ => 0 }`
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{MATCH, "match"},
+		{LPAREN, "("},
+		{IDENT, "xs"},
+		{RPAREN, ")"},
+		{LBRACE, "{"},
+		{LBRACK, "["},
+		{IDENT, "a"},
+		{COMMA, ","},
+		{ELLIPSIS, "..."},
+		{IDENT, "rest"},
+		{RBRACK, "]"},
+		{FATARROW, "=>"},
+		{IDENT, "a"},
+		{COMMA, ","},
+		{IDENT, "_"},
+		{FATARROW, "=>"},
+		{INT, "0"},
+		{RBRACE, "}"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - invalid tokentype. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - invalid token literal. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestMacroTokens(t *testing.T) {
+	input := `let unless = macro(x) { quote(unquote(x)) };`
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{LET, "let"},
+		{IDENT, "unless"},
+		{ASSIGN, "="},
+		{MACRO, "macro"},
+		{LPAREN, "("},
+		{IDENT, "x"},
+		{RPAREN, ")"},
+		{LBRACE, "{"},
+		{IDENT, "quote"},
+		{LPAREN, "("},
+		{IDENT, "unquote"},
+		{LPAREN, "("},
+		{IDENT, "x"},
+		{RPAREN, ")"},
+		{RPAREN, ")"},
+		{RBRACE, "}"},
+		{SEMICOLON, ";"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - invalid tokentype. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - invalid token literal. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNullSafeTokens(t *testing.T) {
+	input := `null ?? a?.[k] ?.b ? x`
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{NULL, "null"},
+		{COALESCE, "??"},
+		{IDENT, "a"},
+		{OPTDOT, "?."},
+		{LBRACK, "["},
+		{IDENT, "k"},
+		{RBRACK, "]"},
+		{OPTDOT, "?."},
+		{IDENT, "b"},
+		{ILLEGAL, "?"},
+		{IDENT, "x"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - invalid tokentype. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - invalid token literal. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package lexer
 
+import "strings"
+
 // A method of Lexer that moves the lexer's cursor to the
 // next character and skips all whitespaces in between.
 func (l *Lexer) EatWhitespaces() {
@@ -25,6 +27,13 @@ func (l *Lexer) PeekChar() byte {
 // A method of Lexer that reads a single character from
 // the lexer input and moves lexer to the next character
 func (l *Lexer) ReadChar() {
+	// Advance the line and column past the current char
+	if l.ch == '\n' {
+		l.line += 1
+		l.column = 0
+	}
+	l.column += 1
+
 	// Check if the end of input has been reached
 	if l.positionNext >= len(l.input) {
 		// Assign character to 0
@@ -68,19 +77,43 @@ func (l *Lexer) ReadNumber() string {
 	return l.input[position:l.positionCurrent]
 }
 
-// A method of Lexer that reads a string from the lexer input
-func (l *Lexer) ReadString() string {
-	// Retrieve the starting position of the number (after the ")
+// A method of Lexer that reads the text of a string from the lexer input, starting after the
+// current character (the opening " or the } that closes an interpolation). The text ends at
+// the closing " or at the ${ of an interpolation, in which case true is also returned.
+// A $ escaped as \$ is a literal $ and does not start an interpolation.
+func (l *Lexer) ReadString() (string, bool) {
+	// Retrieve the starting position of the text (after the current character)
 	position := l.positionCurrent + 1
+	// Declare a builder for the text before escaped characters
+	var text strings.Builder
 
-	// Iterate over the input until a " is encountered
+	// Iterate over the input until a " or a ${ is encountered
 	for {
 		l.ReadChar()
 		if l.ch == '"' || l.ch == 0 {
 			break
 		}
+
+		// Check if a $ is escaped
+		if l.ch == '\\' && l.PeekChar() == '$' {
+			// Collect the text before the escape and continue the text from the $
+			text.WriteString(l.input[position:l.positionCurrent])
+			l.ReadChar()
+			position = l.positionCurrent
+			continue
+		}
+
+		// Check if an interpolation starts
+		if l.ch == '$' && l.PeekChar() == '{' {
+			// Extract the text before the interpolation
+			text.WriteString(l.input[position:l.positionCurrent])
+			// Move the lexer to the { of the interpolation
+			l.ReadChar()
+			return text.String(), true
+		}
 	}
 
 	// Extract the string from the input with the start and current position
-	return l.input[position:l.positionCurrent]
+	text.WriteString(l.input[position:l.positionCurrent])
+	return text.String(), false
 }
@@ -9,8 +9,14 @@ const (
 	INT    = "INT"
 	STRING = "STRING"
 
+	// Interpolated string parts (the text before, between and after the embedded expressions)
+	STRING_HEAD   = "STRING_HEAD"
+	STRING_MIDDLE = "STRING_MIDDLE"
+	STRING_TAIL   = "STRING_TAIL"
+
 	// Arithmetic Operators
 	ASSIGN   = "="
+	FATARROW = "=>"
 	PLUS     = "+"
 	MINUS    = "-"
 	BANG     = "!"
@@ -20,10 +26,18 @@ const (
 	// Logical Operators
 	LT     = "<"
 	GT     = ">"
+	LT_EQ  = "<="
+	GT_EQ  = ">="
 	EQ     = "=="
 	NOT_EQ = "!="
 
+	// Null-coalescing Operator
+	COALESCE = "??"
+
 	// Delimiters
+	DOT       = "."
+	OPTDOT    = "?."
+	ELLIPSIS  = "..."
 	COMMA     = ","
 	COLON     = ":"
 	SEMICOLON = ";"
@@ -37,22 +51,50 @@ const (
 	// Keywords
 	FUNCTION = "FUNCTION"
 	LET      = "LET"
+	CONST    = "CONST"
 	TRUE     = "TRUE"
 	FALSE    = "FALSE"
+	NULL     = "NULL"
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
+	TRY      = "TRY"
+	CATCH    = "CATCH"
+	FINALLY  = "FINALLY"
+	THROW    = "THROW"
+	IN       = "IN"
+	IMPORT   = "IMPORT"
+	AS       = "AS"
+	EXPORT   = "EXPORT"
+	MATCH    = "MATCH"
+	STRUCT   = "STRUCT"
+	ENUM     = "ENUM"
+	MACRO    = "MACRO"
 )
 
 // Language keyword mapper
 var keywords = map[string]TokenType{
-	"fn":     FUNCTION,
-	"let":    LET,
-	"true":   TRUE,
-	"false":  FALSE,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
+	"fn":      FUNCTION,
+	"let":     LET,
+	"const":   CONST,
+	"true":    TRUE,
+	"false":   FALSE,
+	"null":    NULL,
+	"if":      IF,
+	"else":    ELSE,
+	"return":  RETURN,
+	"try":     TRY,
+	"catch":   CATCH,
+	"finally": FINALLY,
+	"throw":   THROW,
+	"in":      IN,
+	"import":  IMPORT,
+	"as":      AS,
+	"export":  EXPORT,
+	"match":   MATCH,
+	"struct":  STRUCT,
+	"enum":    ENUM,
+	"macro":   MACRO,
 }
 
 // A type alias that represents the type of a token
@@ -62,6 +104,10 @@ type TokenType string
 type Token struct {
 	Type    TokenType
 	Literal string
+
+	// Represents the line and column of the token in the input (starting at 1)
+	Line   int
+	Column int
 }
 
 // A constructor function that generates and returns a new
@@ -12,7 +12,15 @@ import (
 const version = "v1.0.0"
 
 func main() {
-	fmt.Println(repl.TUNA2)
+	// Run the script file if one is given
+	if len(os.Args) > 1 {
+		if !repl.RunFile(os.Args[1], os.Stdout, os.Stderr) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Print(repl.TUNA2, "\n")
 	fmt.Printf("The Tuna Programming Language %s [%s-%s].\n", version, strings.Title(runtime.GOOS), strings.ToUpper(runtime.GOARCH))
 	fmt.Println("Welcome to the Tuna REPL. Visit www.github.com/manishmeganathan/tunalang for more information.")
 	repl.StartREPL(os.Stdin, os.Stdout)
@@ -37,6 +37,60 @@ func (l *List) Inspect() string {
 	return out.String()
 }
 
+// A method of List that returns whether it is equal to another object.
+// Lists are equal if they have equal elements in the same order.
+func (l *List) Equals(other Object) bool {
+	// Check that the other object is a List of the same length
+	otherList, ok := other.(*List)
+	if !ok || len(l.Elements) != len(otherList.Elements) {
+		return false
+	}
+
+	// Compare the elements pairwise
+	for idx, element := range l.Elements {
+		if !Equal(element, otherList.Elements[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// A method of List that compares it with another List in lexicographic order.
+// The first pair of elements that are not equal decide the order, otherwise the
+// shorter List is ordered first. Lists with elements that cannot be compared
+// with each other cannot be compared.
+func (l *List) Compare(other Object) (int, bool) {
+	// Check that the other object is a List
+	otherList, ok := other.(*List)
+	if !ok {
+		return 0, false
+	}
+
+	// Compare the elements pairwise
+	for idx := 0; idx < len(l.Elements) && idx < len(otherList.Elements); idx++ {
+		order, ok := Compare(l.Elements[idx], otherList.Elements[idx])
+		if !ok {
+			return 0, false
+		}
+
+		// Return the order of the first unequal elements
+		if order != 0 {
+			return order, true
+		}
+	}
+
+	// Compare the lengths of the lists
+	switch {
+	case len(l.Elements) < len(otherList.Elements):
+		return -1, true
+	case len(l.Elements) > len(otherList.Elements):
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
 // A structure that represents a Map key-value pair
 type MapPair struct {
 	// Represents the key of the key-value pair
@@ -75,3 +129,23 @@ func (h *Map) Inspect() string {
 	// Return the string representation
 	return out.String()
 }
+
+// A method of Map that returns whether it is equal to another object.
+// Maps are equal if they have the same keys with equal values.
+func (h *Map) Equals(other Object) bool {
+	// Check that the other object is a Map of the same size
+	otherMap, ok := other.(*Map)
+	if !ok || len(h.Pairs) != len(otherMap.Pairs) {
+		return false
+	}
+
+	// Compare the values of each key
+	for key, pair := range h.Pairs {
+		otherPair, ok := otherMap.Pairs[key]
+		if !ok || !Equal(pair.Value, otherPair.Value) {
+			return false
+		}
+	}
+
+	return true
+}
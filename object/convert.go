@@ -0,0 +1,545 @@
+package object
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// The struct field tag used to name the fields of converted structs.
+// A field tagged with "-" is skipped during the conversion.
+const fieldTag = "tuna"
+
+var (
+	objectType = reflect.TypeOf((*Object)(nil)).Elem()
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// A function that converts a Go value into an Object. Integers, strings, booleans,
+// slices, arrays, maps, structs (as a Map with String keys), pointers and functions
+// (as a Builtin) are supported. Objects are returned as is and nil becomes NULL.
+func FromGo(value interface{}) (Object, error) {
+	// Check for values that do not require reflection
+	switch value := value.(type) {
+	case nil:
+		return NULL, nil
+	case Object:
+		return value, nil
+	}
+
+	// Convert the value with reflection
+	return fromReflectValue(reflect.ValueOf(value), make(map[reference]bool))
+}
+
+// A function that converts an Object into a Go value. Integers become int64, Strings become
+// string, Booleans become bool, Lists become []interface{}, Maps become map[interface{}]interface{}
+// and NULL becomes nil. Objects without a Go equivalent (such as functions) are returned as is.
+func ToGo(obj Object) interface{} {
+	// Check the type of the object
+	switch obj := obj.(type) {
+
+	// Null and missing values are converted to nil
+	case nil, *Null:
+		return nil
+
+	// Integers are converted to int64
+	case *Integer:
+		return obj.Value
+
+	// Booleans are converted to bool
+	case *Boolean:
+		return obj.Value
+
+	// Strings are converted to string
+	case *String:
+		return obj.Value
+
+	// Lists are converted to []interface{}
+	case *List:
+		values := make([]interface{}, len(obj.Elements))
+		for idx, element := range obj.Elements {
+			values[idx] = ToGo(element)
+		}
+		return values
+
+	// Maps are converted to map[interface{}]interface{}
+	case *Map:
+		values := make(map[interface{}]interface{}, len(obj.Pairs))
+		for _, pair := range obj.Pairs {
+			values[ToGo(pair.Key)] = ToGo(pair.Value)
+		}
+		return values
+
+	// Everything else is returned as is
+	default:
+		return obj
+	}
+}
+
+// A function that converts an Object into the Go value pointed to by target. It
+// allows converting into typed values such as structs, typed slices and typed maps.
+func ToGoValue(obj Object, target interface{}) error {
+	// Check that the target is a non nil pointer
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return fmt.Errorf("target must be a non-nil pointer, got %T", target)
+	}
+
+	// Convert the object into the type of the target
+	value, err := toReflectValue(obj, ptr.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	// Assign the converted value to the target
+	ptr.Elem().Set(value)
+	return nil
+}
+
+// A function that wraps an arbitrary Go function into a Builtin. The arguments of the
+// Builtin are converted to the parameter types of the function. The function may return
+// nothing, a value, an error or a value and an error. A returned error (or a panic) is
+// propagated as an Error object.
+func WrapFunc(fn interface{}) (*Builtin, error) {
+	// Check that the value is a function
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return nil, fmt.Errorf("cannot wrap %T as a builtin function", fn)
+	}
+
+	// Check that the results of the function are supported
+	fnType := fnValue.Type()
+	switch {
+	case fnType.NumOut() > 2:
+		return nil, fmt.Errorf("cannot wrap %s as a builtin function: too many results", fnType)
+	case fnType.NumOut() == 2 && fnType.Out(1) != errorType:
+		return nil, fmt.Errorf("cannot wrap %s as a builtin function: second result must be an error", fnType)
+	}
+
+	return &Builtin{Fn: func(args ...Object) (result Object) {
+		// Convert a panic in the function into an Error
+		defer func() {
+			if r := recover(); r != nil {
+				result = NewError("%v", r)
+			}
+		}()
+
+		// Convert the arguments into Go values
+		values, err := convertArguments(fnType, args)
+		if err != nil {
+			return NewError("%s", err.Error())
+		}
+
+		// Call the function and convert its results
+		return convertResults(fnValue.Call(values))
+	}}, nil
+}
+
+// A function that converts the arguments of a wrapped
+// function into Go values of its parameter types
+func convertArguments(fnType reflect.Type, args []Object) ([]reflect.Value, error) {
+	// Determine the number of fixed parameters
+	fixed := fnType.NumIn()
+	if fnType.IsVariadic() {
+		fixed--
+	}
+
+	// Check the number of arguments
+	if len(args) < fixed || (!fnType.IsVariadic() && len(args) != fixed) {
+		return nil, fmt.Errorf("wrong number of arguments. got=%d, want=%d", len(args), fixed)
+	}
+
+	// Convert each argument to its parameter type
+	values := make([]reflect.Value, len(args))
+	for idx, arg := range args {
+		// Determine the parameter type of the argument
+		var paramType reflect.Type
+		if idx < fixed {
+			paramType = fnType.In(idx)
+		} else {
+			paramType = fnType.In(fixed).Elem()
+		}
+
+		value, err := toReflectValue(arg, paramType)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %s", idx+1, err)
+		}
+		values[idx] = value
+	}
+
+	return values, nil
+}
+
+// A function that converts the results of a wrapped function into an Object
+func convertResults(results []reflect.Value) Object {
+	// Check for a returned error in the last result
+	if len(results) > 0 && results[len(results)-1].Type() == errorType {
+		if err := results[len(results)-1]; !err.IsNil() {
+			return NewError("%s", err.Interface().(error).Error())
+		}
+		results = results[:len(results)-1]
+	}
+
+	// Functions without a result return NULL
+	if len(results) == 0 {
+		return NULL
+	}
+
+	// Convert the result into an object
+	obj, err := fromReflectValue(results[0], make(map[reference]bool))
+	if err != nil {
+		return NewError("%s", err.Error())
+	}
+
+	return obj
+}
+
+// A structure that represents a reference held by a pointer, map or slice value
+type reference struct {
+	// Represents the type of the value
+	typ reflect.Type
+
+	// Represents the address the value refers to
+	ptr uintptr
+
+	// Represents the length of the value (slices of different lengths may share an address)
+	len int
+}
+
+// A function that converts a reflected Go value into an Object. The references being converted
+// are tracked such that a cyclic value (one that refers to itself) is reported as an error.
+func fromReflectValue(value reflect.Value, visiting map[reference]bool) (Object, error) {
+	// Invalid values are converted to NULL
+	if !value.IsValid() {
+		return NULL, nil
+	}
+
+	// Check if the value is a reference that is already being converted
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !value.IsNil() {
+			ref := reference{typ: value.Type(), ptr: value.Pointer()}
+			if value.Kind() == reflect.Slice {
+				ref.len = value.Len()
+			}
+
+			if visiting[ref] {
+				return nil, fmt.Errorf("cannot convert %s to a tuna object: cyclic value", value.Type())
+			}
+
+			// Track the reference until it has been converted
+			visiting[ref] = true
+			defer delete(visiting, ref)
+		}
+	}
+
+	// Objects are returned as is
+	if value.CanInterface() && value.Type().Implements(objectType) && (value.Kind() != reflect.Ptr || !value.IsNil()) {
+		return value.Interface().(Object), nil
+	}
+
+	// Check the kind of the value
+	switch value.Kind() {
+
+	// Booleans are converted to the native Booleans
+	case reflect.Bool:
+		if value.Bool() {
+			return TRUE, nil
+		}
+		return FALSE, nil
+
+	// Signed integers are converted to Integers
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Integer{Value: value.Int()}, nil
+
+	// Unsigned integers are converted to Integers if they fit
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if value.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("cannot convert %d to an integer: out of range", value.Uint())
+		}
+		return &Integer{Value: int64(value.Uint())}, nil
+
+	// Strings are converted to Strings
+	case reflect.String:
+		return &String{Value: value.String()}, nil
+
+	// Slices and arrays are converted to Lists
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return NULL, nil
+		}
+
+		elements := make([]Object, value.Len())
+		for idx := range elements {
+			element, err := fromReflectValue(value.Index(idx), visiting)
+			if err != nil {
+				return nil, err
+			}
+			elements[idx] = element
+		}
+		return &List{Elements: elements}, nil
+
+	// Maps are converted to Maps
+	case reflect.Map:
+		if value.IsNil() {
+			return NULL, nil
+		}
+
+		pairs := make(map[HashKey]MapPair, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key, err := fromReflectValue(iter.Key(), visiting)
+			if err != nil {
+				return nil, err
+			}
+
+			hashable, ok := key.(Hashable)
+			if !ok {
+				return nil, fmt.Errorf("cannot convert %s to a map: unusable as hash key: %s", value.Type(), key.Type())
+			}
+
+			element, err := fromReflectValue(iter.Value(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			pairs[hashable.HashKey()] = MapPair{Key: key, Value: element}
+		}
+		return &Map{Pairs: pairs}, nil
+
+	// Structs are converted to Maps with String keys
+	case reflect.Struct:
+		pairs := make(map[HashKey]MapPair)
+		for _, field := range structFields(value.Type()) {
+			element, err := fromReflectValue(value.FieldByIndex(field.index), visiting)
+			if err != nil {
+				return nil, err
+			}
+
+			key := &String{Value: field.name}
+			pairs[key.HashKey()] = MapPair{Key: key, Value: element}
+		}
+		return &Map{Pairs: pairs}, nil
+
+	// Pointers and interfaces are converted from the value they hold
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return NULL, nil
+		}
+		return fromReflectValue(value.Elem(), visiting)
+
+	// Functions are converted to Builtins
+	case reflect.Func:
+		if value.IsNil() {
+			return NULL, nil
+		}
+		return WrapFunc(value.Interface())
+
+	// Unsupported kinds
+	default:
+		return nil, fmt.Errorf("cannot convert %s to a tuna object", value.Type())
+	}
+}
+
+// A function that converts an Object into a reflected Go value of the given type
+func toReflectValue(obj Object, target reflect.Type) (reflect.Value, error) {
+	// Missing objects are converted as NULL
+	if obj == nil {
+		obj = NULL
+	}
+
+	// Empty interfaces receive the default Go value of the object
+	if target.Kind() == reflect.Interface && target.NumMethod() == 0 {
+		value := reflect.New(target).Elem()
+		if goValue := ToGo(obj); goValue != nil {
+			value.Set(reflect.ValueOf(goValue))
+		}
+		return value, nil
+	}
+
+	// Objects that are assignable to the target are used as is (such as Object parameters)
+	if reflect.TypeOf(obj).AssignableTo(target) {
+		return reflect.ValueOf(obj), nil
+	}
+
+	// NULL is converted to the zero value of nillable types
+	if obj == NULL {
+		switch target.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface, reflect.Func:
+			return reflect.Zero(target), nil
+		}
+	}
+
+	// Create a new value of the target type
+	value := reflect.New(target).Elem()
+	mismatch := fmt.Errorf("cannot convert %s to %s", obj.Type(), target)
+
+	// Check the kind of the target
+	switch target.Kind() {
+
+	// Booleans are converted from Booleans
+	case reflect.Bool:
+		boolean, ok := obj.(*Boolean)
+		if !ok {
+			return value, mismatch
+		}
+		value.SetBool(boolean.Value)
+
+	// Signed integers are converted from Integers that fit
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		integer, ok := obj.(*Integer)
+		if !ok {
+			return value, mismatch
+		}
+		if value.OverflowInt(integer.Value) {
+			return value, fmt.Errorf("cannot convert %d to %s: out of range", integer.Value, target)
+		}
+		value.SetInt(integer.Value)
+
+	// Unsigned integers are converted from non negative Integers that fit
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		integer, ok := obj.(*Integer)
+		if !ok {
+			return value, mismatch
+		}
+		if integer.Value < 0 || value.OverflowUint(uint64(integer.Value)) {
+			return value, fmt.Errorf("cannot convert %d to %s: out of range", integer.Value, target)
+		}
+		value.SetUint(uint64(integer.Value))
+
+	// Strings are converted from Strings
+	case reflect.String:
+		str, ok := obj.(*String)
+		if !ok {
+			return value, mismatch
+		}
+		value.SetString(str.Value)
+
+	// Slices are converted from Lists
+	case reflect.Slice:
+		list, ok := obj.(*List)
+		if !ok {
+			return value, mismatch
+		}
+
+		value = reflect.MakeSlice(target, len(list.Elements), len(list.Elements))
+		for idx, element := range list.Elements {
+			converted, err := toReflectValue(element, target.Elem())
+			if err != nil {
+				return value, err
+			}
+			value.Index(idx).Set(converted)
+		}
+
+	// Arrays are converted from Lists of the same length
+	case reflect.Array:
+		list, ok := obj.(*List)
+		if !ok || len(list.Elements) != target.Len() {
+			return value, mismatch
+		}
+
+		for idx, element := range list.Elements {
+			converted, err := toReflectValue(element, target.Elem())
+			if err != nil {
+				return value, err
+			}
+			value.Index(idx).Set(converted)
+		}
+
+	// Maps are converted from Maps
+	case reflect.Map:
+		mapping, ok := obj.(*Map)
+		if !ok {
+			return value, mismatch
+		}
+
+		value = reflect.MakeMapWithSize(target, len(mapping.Pairs))
+		for _, pair := range mapping.Pairs {
+			key, err := toReflectValue(pair.Key, target.Key())
+			if err != nil {
+				return value, err
+			}
+
+			element, err := toReflectValue(pair.Value, target.Elem())
+			if err != nil {
+				return value, err
+			}
+			value.SetMapIndex(key, element)
+		}
+
+	// Structs are converted from Maps with String keys
+	case reflect.Struct:
+		mapping, ok := obj.(*Map)
+		if !ok {
+			return value, mismatch
+		}
+
+		for _, field := range structFields(target) {
+			pair, ok := mapping.Pairs[(&String{Value: field.name}).HashKey()]
+			if !ok {
+				continue
+			}
+
+			converted, err := toReflectValue(pair.Value, target.FieldByIndex(field.index).Type)
+			if err != nil {
+				return value, fmt.Errorf("field %s: %s", field.name, err)
+			}
+			value.FieldByIndex(field.index).Set(converted)
+		}
+
+	// Pointers are converted from the value they point to
+	case reflect.Ptr:
+		element, err := toReflectValue(obj, target.Elem())
+		if err != nil {
+			return value, err
+		}
+
+		value = reflect.New(target.Elem())
+		value.Elem().Set(element)
+
+	// Unsupported kinds
+	default:
+		return value, mismatch
+	}
+
+	return value, nil
+}
+
+// A structure that represents a converted field of a struct
+type structField struct {
+	// Represents the name of the field in the converted Map
+	name string
+
+	// Represents the index sequence of the field in the struct
+	index []int
+}
+
+// A function that returns the exported fields of a struct type with their
+// converted names. Fields are named by their tag or by their Go name.
+func structFields(structType reflect.Type) []structField {
+	fields := []structField{}
+
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		// Skip unexported fields
+		if field.PkgPath != "" {
+			continue
+		}
+
+		// Determine the name of the field from its tag
+		name := field.Name
+		if tag, ok := field.Tag.Lookup(fieldTag); ok {
+			tag = strings.Split(tag, ",")[0]
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		fields = append(fields, structField{name: name, index: field.Index})
+	}
+
+	return fields
+}
@@ -3,6 +3,15 @@ package object
 import (
 	"fmt"
 	"hash/fnv"
+	"strings"
+)
+
+// The native Null and Boolean objects. They are immutable and are shared
+// by all evaluators such that they can be compared by identity.
+var (
+	NULL  = &Null{}
+	TRUE  = &Boolean{Value: true}
+	FALSE = &Boolean{Value: false}
 )
 
 // A structure that represents a Null object
@@ -14,6 +23,12 @@ func (n *Null) Type() ObjectType { return NULL_OBJ }
 // A method of Null that returns the string value of the Null
 func (n *Null) Inspect() string { return "null" }
 
+// A method of Null that returns whether it is equal to another object
+func (n *Null) Equals(other Object) bool {
+	_, ok := other.(*Null)
+	return ok
+}
+
 // A structure that represents an Integer object
 type Integer struct {
 	// Represents the value of the Integer
@@ -26,6 +41,31 @@ func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
 // A method of Integer that returns the string value of the Integer
 func (i *Integer) Inspect() string { return fmt.Sprintf("%d", i.Value) }
 
+// A method of Integer that returns whether it is equal to another object
+func (i *Integer) Equals(other Object) bool {
+	otherInt, ok := other.(*Integer)
+	return ok && i.Value == otherInt.Value
+}
+
+// A method of Integer that compares it with another Integer
+func (i *Integer) Compare(other Object) (int, bool) {
+	// Check that the other object is an Integer
+	otherInt, ok := other.(*Integer)
+	if !ok {
+		return 0, false
+	}
+
+	// Compare the integer values
+	switch {
+	case i.Value < otherInt.Value:
+		return -1, true
+	case i.Value > otherInt.Value:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
 // A method of Integer that return the HashKey of the object
 func (i *Integer) HashKey() HashKey {
 	// Create and return the HashKey object from the integer value
@@ -44,6 +84,12 @@ func (b *Boolean) Type() ObjectType { return BOOLEAN_OBJ }
 // A method of Boolean that returns the string value of the Boolean
 func (b *Boolean) Inspect() string { return fmt.Sprintf("%t", b.Value) }
 
+// A method of Boolean that returns whether it is equal to another object
+func (b *Boolean) Equals(other Object) bool {
+	otherBool, ok := other.(*Boolean)
+	return ok && b.Value == otherBool.Value
+}
+
 // A method of Boolean that returns the HashKey of the object
 func (b *Boolean) HashKey() HashKey {
 	// Declare an unsigned int64
@@ -74,6 +120,28 @@ func (s *String) Type() ObjectType { return STRING_OBJ }
 // A method of String that returns the string value of the String
 func (s *String) Inspect() string { return s.Value }
 
+// A method of String that returns the string form of the String, which is its value
+func (s *String) Str() string { return s.Value }
+
+// A method of String that returns whether it is equal to another object
+func (s *String) Equals(other Object) bool {
+	otherStr, ok := other.(*String)
+	return ok && s.Value == otherStr.Value
+}
+
+// A method of String that compares it with another String by the order of their
+// Unicode code points (which is the byte order of their UTF-8 encoding)
+func (s *String) Compare(other Object) (int, bool) {
+	// Check that the other object is a String
+	otherStr, ok := other.(*String)
+	if !ok {
+		return 0, false
+	}
+
+	// Compare the string values
+	return strings.Compare(s.Value, otherStr.Value), true
+}
+
 // A method of String that returns the HashKey of the object
 func (s *String) HashKey() HashKey {
 	// Create new 64bit FNV hasher
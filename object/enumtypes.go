@@ -0,0 +1,131 @@
+package object
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// A structure that represents an Enum object, the type created by an enum declaration
+type Enum struct {
+	// Represents the name of the enum
+	Name string
+	// Represents the variants of the enum by their name
+	Variants map[string]*EnumVariant
+}
+
+// A method of Enum that returns the Enum value type
+func (e *Enum) Type() ObjectType { return ENUM_OBJ }
+
+// A method of Enum that returns the string value of the Enum object
+func (e *Enum) Inspect() string { return fmt.Sprintf("<enum %s>", e.Name) }
+
+// A structure that represents an EnumVariant object, a variant declared by an enum. A variant
+// with payload fields is called to construct its values, a variant without fields is a value.
+type EnumVariant struct {
+	// Represents the enum of the variant
+	Enum *Enum
+	// Represents the name of the variant
+	Name string
+	// Represents the names of the payload fields of the variant
+	Fields []string
+}
+
+// A method of EnumVariant that returns the EnumVariant value type
+func (v *EnumVariant) Type() ObjectType { return ENUM_VARIANT_OBJ }
+
+// A method of EnumVariant that returns the string value of the EnumVariant object
+func (v *EnumVariant) Inspect() string { return fmt.Sprintf("<variant %s>", v.QualifiedName()) }
+
+// A method of EnumVariant that returns its name qualified by the name of its enum
+func (v *EnumVariant) QualifiedName() string { return v.Enum.Name + "." + v.Name }
+
+// A structure that represents an EnumValue object, a value of a variant of an enum
+type EnumValue struct {
+	// Represents the variant of the value
+	Variant *EnumVariant
+	// Represents the values of the payload fields in the order of declaration
+	Payload []Object
+}
+
+// A method of EnumValue that returns the EnumValue value type
+func (ev *EnumValue) Type() ObjectType { return ENUM_VALUE_OBJ }
+
+// A method of EnumValue that returns the string value of the EnumValue
+func (ev *EnumValue) Inspect() string {
+	// Create a string buffer
+	var out bytes.Buffer
+
+	// Add the qualified name of the variant
+	out.WriteString(ev.Variant.QualifiedName())
+
+	// Add the payload of the value
+	if len(ev.Variant.Fields) > 0 {
+		payload := []string{}
+		for _, element := range ev.Payload {
+			payload = append(payload, element.Inspect())
+		}
+
+		out.WriteString("(")
+		out.WriteString(strings.Join(payload, ", "))
+		out.WriteString(")")
+	}
+
+	// Return the string representation
+	return out.String()
+}
+
+// A method of EnumValue that returns the value of a payload field given its name
+func (ev *EnumValue) Field(name string) (Object, bool) {
+	// Iterate over the payload fields
+	for idx, field := range ev.Variant.Fields {
+		if field == name {
+			return ev.Payload[idx], true
+		}
+	}
+
+	return nil, false
+}
+
+// A method of EnumValue that returns whether it is equal to another object.
+// Enum values are equal if they are of the same variant and have equal payloads.
+func (ev *EnumValue) Equals(other Object) bool {
+	// Check that the other object is a value of the same variant
+	otherValue, ok := other.(*EnumValue)
+	if !ok || ev.Variant != otherValue.Variant {
+		return false
+	}
+
+	// Compare the payloads pairwise
+	for idx, element := range ev.Payload {
+		if !Equal(element, otherValue.Payload[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// A method of EnumValue that returns the HashKey of the object. The key is derived from
+// the qualified name of the variant and the HashKeys of the payload, which must be
+// hashable (as checked by HashKeyOf).
+func (ev *EnumValue) HashKey() HashKey {
+	// Create new 64bit FNV hasher
+	h := fnv.New64a()
+	// Write the qualified name of the variant to the hasher
+	h.Write([]byte(ev.Variant.QualifiedName()))
+
+	// Write the HashKeys of the payload to the hasher
+	for _, element := range ev.Payload {
+		key, _ := HashKeyOf(element)
+
+		h.Write([]byte{0})
+		h.Write([]byte(key.Type))
+		binary.Write(h, binary.LittleEndian, key.Value)
+	}
+
+	// Return the HashKey object
+	return HashKey{Type: ev.Type(), Value: h.Sum64()}
+}
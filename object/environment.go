@@ -4,6 +4,8 @@ package object
 type Environment struct {
 	// Represents the memory pool of stored objects
 	store map[string]Object
+	// Represents the names of the read-only bindings in the store (nil until a constant is bound)
+	constants map[string]bool
 	// Represents the outer environment scope
 	outer *Environment
 }
@@ -44,10 +46,37 @@ func (e *Environment) Get(name string) (Object, bool) {
 	return obj, ok
 }
 
-// A method of Environment to add a value to store
+// A method of Environment to add a value to store. An Error is returned
+// instead if the name is bound to a constant in this environment.
 func (e *Environment) Set(name string, val Object) Object {
+	// Check if the name is bound to a constant
+	if e.constants[name] {
+		// Return an Error
+		return NewErrorOfKind(ASSIGNMENT_ERROR, "cannot reassign constant: %s", name)
+	}
+
 	// Add the value to the store
 	e.store[name] = val
 	// Return the value as an acknowledgement
 	return val
 }
+
+// A method of Environment to add a read-only value to the store. A constant can not be
+// replaced in this environment but can be shadowed by bindings in enclosed environments.
+func (e *Environment) SetConst(name string, val Object) Object {
+	// Add the value to the store
+	if result := e.Set(name, val); result != val {
+		// Return the Error
+		return result
+	}
+
+	// Check if the constants hash map is initialized
+	if e.constants == nil {
+		e.constants = make(map[string]bool)
+	}
+
+	// Mark the binding as read-only
+	e.constants[name] = true
+	// Return the value as an acknowledgement
+	return val
+}
@@ -0,0 +1,49 @@
+package object
+
+import (
+	"strings"
+
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// A structure that represents a Macro object, which is bound by a top-level let statement
+// with a macro literal and expanded before the program is evaluated
+type Macro struct {
+	// Represents the name of the macro
+	Name string
+	// Represents the macro parameters
+	Parameters []*syntaxtree.Identifier
+	// Represents the macro body
+	Body *syntaxtree.BlockStatement
+	// Represents the macro execution environment (scope)
+	Env *Environment
+}
+
+// A method of Macro that returns the Macro value type
+func (m *Macro) Type() ObjectType { return MACRO_OBJ }
+
+// A method of Macro that returns the string value of the Macro object
+func (m *Macro) Inspect() string {
+	// Collect the macro parameters
+	params := []string{}
+	for _, p := range m.Parameters {
+		params = append(params, p.String())
+	}
+
+	return "macro " + m.Name + "(" + strings.Join(params, ", ") + ") {\n" + m.Body.String() + "\n}"
+}
+
+// A structure that represents a Quote object, an unevaluated syntax tree node
+type Quote struct {
+	// Represents the quoted node
+	Node syntaxtree.Node
+}
+
+// A method of Quote that returns the Quote value type
+func (q *Quote) Type() ObjectType { return QUOTE_OBJ }
+
+// A method of Quote that returns the string value of the Quote object
+func (q *Quote) Inspect() string { return "quote(" + q.Node.String() + ")" }
+
+// A method of Quote that returns the string form of the Quote object, the source of its node
+func (q *Quote) Str() string { return q.Node.String() }
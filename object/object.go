@@ -15,6 +15,7 @@ const (
 	ERROR_OBJ        = "ERROR"
 	FUNCTION_OBJ     = "FUNCTION"
 	BUILTIN_OBJ      = "BUILTIN"
+	MODULE_OBJ       = "MODULE"
 
 	INTEGER_OBJ = "INTEGER"
 	BOOLEAN_OBJ = "BOOLEAN"
@@ -22,6 +23,16 @@ const (
 
 	LIST_OBJ = "LIST"
 	MAP_OBJ  = "MAP"
+
+	STRUCT_OBJ   = "STRUCT"
+	INSTANCE_OBJ = "INSTANCE"
+
+	ENUM_OBJ         = "ENUM"
+	ENUM_VARIANT_OBJ = "ENUM_VARIANT"
+	ENUM_VALUE_OBJ   = "ENUM_VALUE"
+
+	MACRO_OBJ = "MACRO"
+	QUOTE_OBJ = "QUOTE"
 )
 
 // A type alias that represents the type of an object
@@ -47,6 +58,88 @@ type Hashable interface {
 	HashKey() HashKey
 }
 
+// A function that returns the HashKey of an object and whether the object can be hashed.
+// Objects that implement Hashable can be hashed, except for enum values with a payload
+// that cannot be hashed.
+func HashKeyOf(obj Object) (HashKey, bool) {
+	// Check if the object implements Hashable
+	hashable, ok := obj.(Hashable)
+	if !ok {
+		return HashKey{}, false
+	}
+
+	// Check that the payload of an enum value can be hashed
+	if value, ok := obj.(*EnumValue); ok {
+		for _, element := range value.Payload {
+			if _, ok := HashKeyOf(element); !ok {
+				return HashKey{}, false
+			}
+		}
+	}
+
+	return hashable.HashKey(), true
+}
+
+// An interface implemented by objects that are compared by value
+type Equatable interface {
+	Equals(other Object) bool
+}
+
+// An interface implemented by objects whose string form (as converted by
+// str and string interpolation) differs from their inspected form
+type Stringer interface {
+	Str() string
+}
+
+// A function that returns the string form of an object. Objects that implement
+// Stringer are converted with Str and all other objects with Inspect.
+func Str(obj Object) string {
+	// Check if the object has a string form
+	if stringer, ok := obj.(Stringer); ok {
+		return stringer.Str()
+	}
+
+	// Return the inspected form
+	return obj.Inspect()
+}
+
+// A function that returns whether two objects are equal. Objects that implement
+// Equatable are compared by value and all other objects are compared by identity.
+func Equal(left, right Object) bool {
+	// Check if the objects are identical
+	if left == right {
+		return true
+	}
+
+	// Check if the left object is compared by value
+	if equatable, ok := left.(Equatable); ok {
+		return equatable.Equals(right)
+	}
+
+	// Objects are not equal
+	return false
+}
+
+// An interface implemented by objects that have an ordering. Compare returns a negative
+// number, zero or a positive number if the object is less than, equal to or greater than
+// the other object and false if the objects cannot be compared with each other.
+type Comparable interface {
+	Compare(other Object) (int, bool)
+}
+
+// A function that compares two objects by their ordering and returns a negative number,
+// zero or a positive number if the left object is less than, equal to or greater than
+// the right object. It returns false if the objects cannot be compared with each other.
+func Compare(left, right Object) (int, bool) {
+	// Check if the left object has an ordering
+	if comparable, ok := left.(Comparable); ok {
+		return comparable.Compare(right)
+	}
+
+	// Objects cannot be compared
+	return 0, false
+}
+
 // A structure that represents a Returned object
 type ReturnValue struct {
 	// Represents the returned object
@@ -59,10 +152,50 @@ func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
 // A method of ReturnValue that returns the string value of the Returned object
 func (rv *ReturnValue) Inspect() string { return rv.Value.Inspect() }
 
+// Represents the kinds of Error objects
+const (
+	RUNTIME_ERROR     = "RuntimeError"
+	TYPE_ERROR        = "TypeError"
+	NAME_ERROR        = "NameError"
+	ARGUMENT_ERROR    = "ArgumentError"
+	MEMORY_ERROR      = "MemoryError"
+	CANCELLED_ERROR   = "CancelledError"
+	THROWN_ERROR      = "ThrownError"
+	INDEX_ERROR       = "IndexError"
+	ASSIGNMENT_ERROR  = "AssignmentError"
+	DECLARATION_ERROR = "DeclarationError"
+	IMPORT_ERROR      = "ImportError"
+	MATCH_ERROR       = "MatchError"
+	MACRO_ERROR       = "MacroError"
+)
+
 // A structure that represents an Error object
 type Error struct {
 	// Represents the error message
 	Message string
+
+	// Represents the kind of the error
+	Kind string
+
+	// Represents the value thrown by a throw statement (nil for runtime errors)
+	Value Object
+
+	// Represents the line and column where the error occurred (0 if unknown)
+	Line   int
+	Column int
+
+	// Represents the call stack of the error, starting with the innermost call
+	Stack []Frame
+}
+
+// A structure that represents a function call on the stack of an Error
+type Frame struct {
+	// Represents the name of the called function
+	Function string
+
+	// Represents the line and column of the call site
+	Line   int
+	Column int
 }
 
 // A method of Error that returns the Error value type
@@ -71,15 +204,55 @@ func (e *Error) Type() ObjectType { return ERROR_OBJ }
 // A method of Error that returns the string value of the Error object
 func (e *Error) Inspect() string { return "ERROR: " + e.Message }
 
-// A constructor function that generates and returns a new Error
-// for a given message and some variadic interface
+// A method of Error that returns a traceback of the Error object that lists
+// the call sites of its stack (most recent call last) and its position
+func (e *Error) Traceback() string {
+	// Declare a bytes buffer
+	var out bytes.Buffer
+	out.WriteString("Traceback (most recent call last):\n")
+
+	// Add the call sites from the outermost to the innermost call. Each call site
+	// is located in the function that was called by the next outer frame.
+	caller := "<program>"
+	for idx := len(e.Stack) - 1; idx >= 0; idx-- {
+		frame := e.Stack[idx]
+		out.WriteString(fmt.Sprintf("  line %d, column %d, in %s\n", frame.Line, frame.Column, caller))
+		caller = frame.Function
+	}
+
+	// Add the position of the error if it is known
+	if e.Line > 0 {
+		out.WriteString(fmt.Sprintf("  line %d, column %d, in %s\n", e.Line, e.Column, caller))
+	}
+
+	// Add the error message
+	out.WriteString(e.Inspect())
+	return out.String()
+}
+
+// A method of Error that returns the error message. It
+// allows an Error object to be used as a Go error value.
+func (e *Error) Error() string { return e.Message }
+
+// A constructor function that generates and returns a new runtime
+// Error for a given message and some variadic interface
 func NewError(format string, a ...interface{}) *Error {
-	return &Error{Message: fmt.Sprintf(format, a...)}
+	return NewErrorOfKind(RUNTIME_ERROR, format, a...)
+}
+
+// A constructor function that generates and returns a new Error of
+// the given kind for a given message and some variadic interface
+func NewErrorOfKind(kind string, format string, a ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, a...)}
 }
 
 // A type alias for built in function objects
 type BuiltinFunction func(args ...Object) Object
 
+// A type alias for built in methods, which are called with the
+// object that the method is accessed on (the receiver)
+type MethodFunction func(receiver Object, args ...Object) Object
+
 // A structure that represents a Builtin Function
 type Builtin struct {
 	// Represents the built-in function
@@ -94,6 +267,8 @@ func (b *Builtin) Inspect() string { return "builtin function" }
 
 // A structure that represents a Function object
 type Function struct {
+	// Represents the name of the function (empty for anonymous functions)
+	Name string
 	// Represents the function parameters
 	Parameters []*syntaxtree.Identifier
 	// Represents the function body
@@ -116,8 +291,11 @@ func (f *Function) Inspect() string {
 		params = append(params, p.String())
 	}
 
-	// Add the fn keyword and parameters
+	// Add the fn keyword, the name and parameters
 	out.WriteString("fn")
+	if f.Name != "" {
+		out.WriteString(" " + f.Name)
+	}
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(") {\n")
@@ -128,3 +306,19 @@ func (f *Function) Inspect() string {
 	// Return string buffer
 	return out.String()
 }
+
+// A structure that represents a Module object
+type Module struct {
+	// Represents the path of the module as it was imported
+	Name string
+	// Represents the resolved path of the module file
+	Path string
+	// Represents the bindings exported by the module
+	Exports map[string]Object
+}
+
+// A method of Module that returns the Module value type
+func (m *Module) Type() ObjectType { return MODULE_OBJ }
+
+// A method of Module that returns the string value of the Module object
+func (m *Module) Inspect() string { return fmt.Sprintf("<module %s>", m.Name) }
@@ -1,6 +1,12 @@
 package object
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
 
 func TestStringMapKey(t *testing.T) {
 	hello1 := &String{Value: "Hello World"}
@@ -20,3 +26,298 @@ func TestStringMapKey(t *testing.T) {
 		t.Errorf("strings with different content have same hash keys")
 	}
 }
+
+type testUser struct {
+	Name    string `tuna:"name"`
+	Age     int    `tuna:"age"`
+	Admin   bool
+	Tags    []string `tuna:"tags"`
+	Secret  string   `tuna:"-"`
+	private int
+}
+
+func TestEnumValueHashKey(t *testing.T) {
+	shape := &Enum{Name: "Shape"}
+	circle := &EnumVariant{Enum: shape, Name: "Circle", Fields: []string{"r"}}
+	square := &EnumVariant{Enum: shape, Name: "Square", Fields: []string{"s"}}
+
+	one := &EnumValue{Variant: circle, Payload: []Object{&Integer{Value: 1}}}
+	other, _ := HashKeyOf(&EnumValue{Variant: circle, Payload: []Object{&Integer{Value: 1}}})
+
+	if key, ok := HashKeyOf(one); !ok || key != other {
+		t.Errorf("enum values with same variant and payload have different hash keys")
+	}
+
+	for _, diff := range []*EnumValue{
+		{Variant: circle, Payload: []Object{&Integer{Value: 2}}},
+		{Variant: circle, Payload: []Object{&String{Value: "1"}}},
+		{Variant: square, Payload: []Object{&Integer{Value: 1}}},
+	} {
+		if key, _ := HashKeyOf(diff); key == other {
+			t.Errorf("enum values %s and %s have same hash keys", one.Inspect(), diff.Inspect())
+		}
+	}
+
+	if _, ok := HashKeyOf(&EnumValue{Variant: circle, Payload: []Object{&List{}}}); ok {
+		t.Errorf("enum value with a List payload is hashable")
+	}
+}
+
+func TestFromGo(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected string
+	}{
+		{nil, "null"},
+		{42, "42"},
+		{uint8(7), "7"},
+		{"tuna", "tuna"},
+		{true, "true"},
+		{[]int{1, 2, 3}, "[1, 2, 3]"},
+		{[2]string{"a", "b"}, "[a, b]"},
+		{map[string]int{"one": 1}, "{one: 1}"},
+		{&testUser{Name: "ann", Tags: []string{}}, ""},
+		{&Integer{Value: 5}, "5"},
+	}
+
+	for _, tt := range tests {
+		obj, err := FromGo(tt.input)
+		if err != nil {
+			t.Errorf("unexpected error for %#v: %s", tt.input, err)
+			continue
+		}
+
+		if tt.expected != "" && obj.Inspect() != tt.expected {
+			t.Errorf("wrong conversion for %#v. expected=%q, got=%q", tt.input, tt.expected, obj.Inspect())
+		}
+	}
+
+	obj, _ := FromGo(testUser{Name: "ann", Age: 30, Admin: true, Secret: "x"})
+	mapping, ok := obj.(*Map)
+	if !ok {
+		t.Fatalf("object is not Map. got=%T", obj)
+	}
+
+	for _, key := range []string{"name", "age", "Admin", "tags"} {
+		if _, ok := mapping.Pairs[(&String{Value: key}).HashKey()]; !ok {
+			t.Errorf("struct field %q was not converted", key)
+		}
+	}
+
+	if len(mapping.Pairs) != 4 {
+		t.Errorf("struct converted to wrong number of pairs. got=%d", len(mapping.Pairs))
+	}
+
+	if _, err := FromGo(3.5); err == nil {
+		t.Errorf("expected an error for an unsupported type")
+	}
+
+	if _, err := FromGo(map[[1]int]int{{1}: 1}); err == nil {
+		t.Errorf("expected an error for an unhashable key")
+	}
+
+	// Cyclic values are reported as errors
+	type node struct{ Next *node }
+	cyclicNode := &node{}
+	cyclicNode.Next = cyclicNode
+	cyclicMap := map[string]interface{}{}
+	cyclicMap["self"] = cyclicMap
+	cyclicSlice := []interface{}{nil}
+	cyclicSlice[0] = cyclicSlice
+
+	for _, input := range []interface{}{cyclicNode, cyclicMap, cyclicSlice} {
+		if _, err := FromGo(input); err == nil || !strings.Contains(err.Error(), "cyclic value") {
+			t.Errorf("expected a cyclic value error for %T. got=%v", input, err)
+		}
+	}
+
+	// Shared values that are not cyclic are converted
+	shared := []int{1}
+	if obj, err := FromGo([][]int{shared, shared}); err != nil || obj.Inspect() != "[[1], [1]]" {
+		t.Errorf("wrong conversion of shared values. got=%v, %v", obj, err)
+	}
+}
+
+func TestToGo(t *testing.T) {
+	list := &List{Elements: []Object{&Integer{Value: 1}, &String{Value: "a"}, TRUE, NULL}}
+	values, ok := ToGo(list).([]interface{})
+	if !ok || len(values) != 4 {
+		t.Fatalf("list was not converted to a slice. got=%#v", ToGo(list))
+	}
+
+	if values[0] != int64(1) || values[1] != "a" || values[2] != true || values[3] != nil {
+		t.Errorf("list elements were converted incorrectly. got=%#v", values)
+	}
+
+	source, _ := FromGo(map[string]interface{}{"name": "bob", "age": 41, "tags": []string{"x", "y"}, "extra": 1})
+
+	var user testUser
+	if err := ToGoValue(source, &user); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if user.Name != "bob" || user.Age != 41 || len(user.Tags) != 2 || user.Tags[1] != "y" {
+		t.Errorf("struct was converted incorrectly. got=%+v", user)
+	}
+
+	var small int8
+	if err := ToGoValue(&Integer{Value: 300}, &small); err == nil {
+		t.Errorf("expected an error for an out of range integer")
+	}
+
+	var text string
+	if err := ToGoValue(&Integer{Value: 3}, &text); err == nil {
+		t.Errorf("expected an error for a mismatched type")
+	}
+}
+
+func TestWrapFunc(t *testing.T) {
+	tests := []struct {
+		fn       interface{}
+		args     []Object
+		expected string
+	}{
+		{func(a, b int) int { return a + b }, []Object{&Integer{Value: 2}, &Integer{Value: 3}}, "5"},
+		{func(s string, n ...int) string { return s + strings.Repeat("!", len(n)) }, []Object{&String{Value: "hi"}, &Integer{Value: 1}, &Integer{Value: 1}}, "hi!!"},
+		{func() {}, []Object{}, "null"},
+		{func(xs []int) (int, error) { return len(xs), nil }, []Object{&List{Elements: []Object{TRUE}}}, "ERROR: argument 1: cannot convert BOOLEAN to int"},
+		{func(n int) (int, error) { return 0, errors.New("bad input") }, []Object{&Integer{Value: 1}}, "ERROR: bad input"},
+		{func(n int) int { return n }, []Object{}, "ERROR: wrong number of arguments. got=0, want=1"},
+		{func(obj Object) string { return string(obj.Type()) }, []Object{NULL}, "NULL"},
+		{func(v interface{}) interface{} { return v }, []Object{&String{Value: "x"}}, "x"},
+		{func() int { panic("boom") }, []Object{}, "ERROR: boom"},
+		{func(obj Object) string { return string(obj.Type()) }, []Object{nil}, "NULL"},
+		{func(xs []int) int { return len(xs) }, []Object{nil}, "0"},
+		{func(n int) int { return n }, []Object{nil}, "ERROR: argument 1: cannot convert NULL to int"},
+	}
+
+	for _, tt := range tests {
+		builtin, err := WrapFunc(tt.fn)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			continue
+		}
+
+		if result := builtin.Fn(tt.args...); result.Inspect() != tt.expected {
+			t.Errorf("wrong result. expected=%q, got=%q", tt.expected, result.Inspect())
+		}
+	}
+
+	if _, err := WrapFunc(func() (int, int) { return 0, 0 }); err == nil {
+		t.Errorf("expected an error for unsupported results")
+	}
+
+	if _, err := WrapFunc(5); err == nil {
+		t.Errorf("expected an error for a non function")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	list := &List{Elements: []Object{&Integer{Value: 1}, &String{Value: "a"}}}
+	mapping, _ := FromGo(map[string]interface{}{"k": []int{1}})
+	other, _ := FromGo(map[string]interface{}{"k": []int{1}})
+	point := &Struct{Name: "Point", Fields: []string{"x"}}
+	instance := &Instance{Struct: point, Fields: map[string]Object{"x": &Integer{Value: 1}}}
+
+	tests := []struct {
+		left, right Object
+		expected    bool
+	}{
+		{&Integer{Value: 1}, &Integer{Value: 1}, true},
+		{&Integer{Value: 1}, &String{Value: "1"}, false},
+		{&String{Value: "x"}, &String{Value: "x"}, true},
+		{TRUE, &Boolean{Value: true}, true},
+		{NULL, &Null{}, true},
+		{list, &List{Elements: []Object{&Integer{Value: 1}, &String{Value: "a"}}}, true},
+		{list, &List{Elements: []Object{&Integer{Value: 1}}}, false},
+		{mapping, other, true},
+		{mapping, list, false},
+		{&Builtin{}, &Builtin{}, false},
+		{instance, &Instance{Struct: point, Fields: map[string]Object{"x": &Integer{Value: 1}}}, true},
+		{instance, &Instance{Struct: point, Fields: map[string]Object{"x": &Integer{Value: 2}}}, false},
+		{instance, &Instance{Struct: &Struct{Name: "Point", Fields: []string{"x"}}, Fields: map[string]Object{"x": &Integer{Value: 1}}}, false},
+	}
+
+	for _, tt := range tests {
+		if Equal(tt.left, tt.right) != tt.expected {
+			t.Errorf("Equal(%s, %s) wrong. expected=%t", tt.left.Inspect(), tt.right.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		left, right Object
+		order       int
+		ok          bool
+	}{
+		{&Integer{Value: 1}, &Integer{Value: 2}, -1, true},
+		{&Integer{Value: 2}, &Integer{Value: 2}, 0, true},
+		{&String{Value: "b"}, &String{Value: "a"}, 1, true},
+		{&String{Value: "ä"}, &String{Value: "z"}, 1, true},
+		{&List{Elements: []Object{&Integer{Value: 1}}}, &List{Elements: []Object{&Integer{Value: 1}, &Integer{Value: 0}}}, -1, true},
+		{&List{Elements: []Object{&String{Value: "b"}}}, &List{Elements: []Object{&String{Value: "a"}, &String{Value: "z"}}}, 1, true},
+		{&Integer{Value: 1}, &String{Value: "1"}, 0, false},
+		{TRUE, FALSE, 0, false},
+		{&List{Elements: []Object{TRUE}}, &List{Elements: []Object{FALSE}}, 0, false},
+	}
+
+	for _, tt := range tests {
+		order, ok := Compare(tt.left, tt.right)
+		if ok != tt.ok || order != tt.order {
+			t.Errorf("Compare(%s, %s) wrong. expected=(%d, %t), got=(%d, %t)",
+				tt.left.Inspect(), tt.right.Inspect(), tt.order, tt.ok, order, ok)
+		}
+	}
+}
+
+func TestEnvironmentConstants(t *testing.T) {
+	env := NewEnvironment()
+	if _, ok := env.Set("y", &Integer{Value: 1}).(*Error); ok {
+		t.Errorf("binding a variable without constants returned an Error")
+	}
+
+	env.SetConst("x", &Integer{Value: 1})
+
+	if _, ok := env.Set("x", &Integer{Value: 2}).(*Error); !ok {
+		t.Errorf("rebinding a constant did not return an Error")
+	}
+
+	if _, ok := env.SetConst("x", &Integer{Value: 2}).(*Error); !ok {
+		t.Errorf("redeclaring a constant did not return an Error")
+	}
+
+	if val, _ := env.Get("x"); val.(*Integer).Value != 1 {
+		t.Errorf("constant was replaced. got=%d", val.(*Integer).Value)
+	}
+
+	inner := NewEnclosedEnvironment(env)
+	if _, ok := inner.Set("x", &Integer{Value: 3}).(*Error); ok {
+		t.Errorf("shadowing a constant returned an Error")
+	}
+
+	if _, ok := inner.Set("x", &Integer{Value: 4}).(*Error); ok {
+		t.Errorf("rebinding a shadowed constant returned an Error")
+	}
+
+	if val, _ := env.Get("x"); val.(*Integer).Value != 1 {
+		t.Errorf("shadowing replaced the constant. got=%d", val.(*Integer).Value)
+	}
+}
+
+func TestQuoteInspect(t *testing.T) {
+	node := &syntaxtree.InfixExpression{
+		Left:     &syntaxtree.Identifier{Value: "x"},
+		Operator: "+",
+		Right:    &syntaxtree.Identifier{Value: "y"},
+	}
+	quote := &Quote{Node: node}
+
+	if quote.Inspect() != "quote((x + y))" {
+		t.Errorf("quote.Inspect() wrong. got=%q", quote.Inspect())
+	}
+
+	if Str(quote) != "(x + y)" {
+		t.Errorf("Str(quote) wrong. got=%q", Str(quote))
+	}
+}
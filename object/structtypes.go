@@ -0,0 +1,90 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A structure that represents a Struct object, the type created by a struct declaration
+type Struct struct {
+	// Represents the name of the struct
+	Name string
+	// Represents the names of the fields in the order of declaration
+	Fields []string
+	// Represents the methods of the struct by their name
+	Methods map[string]*Function
+}
+
+// A method of Struct that returns the Struct value type
+func (s *Struct) Type() ObjectType { return STRUCT_OBJ }
+
+// A method of Struct that returns the string value of the Struct object
+func (s *Struct) Inspect() string { return fmt.Sprintf("<struct %s>", s.Name) }
+
+// A method of Struct that returns whether it declares a field with the given name
+func (s *Struct) HasField(name string) bool {
+	// Iterate over the fields
+	for _, field := range s.Fields {
+		if field == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// A structure that represents an Instance object, a value of a Struct
+type Instance struct {
+	// Represents the struct of the instance
+	Struct *Struct
+	// Represents the values of the fields by their name
+	Fields map[string]Object
+}
+
+// A method of Instance that returns the Instance value type
+func (i *Instance) Type() ObjectType { return INSTANCE_OBJ }
+
+// A method of Instance that returns the string value of the Instance
+func (i *Instance) Inspect() string {
+	// Create a string buffer
+	var out bytes.Buffer
+
+	// Collect the fields in the order of declaration
+	fields := []string{}
+	for _, field := range i.Struct.Fields {
+		fields = append(fields, field+": "+i.Fields[field].Inspect())
+	}
+
+	// Add the name of the struct and its fields
+	out.WriteString(i.Struct.Name)
+	if len(fields) == 0 {
+		out.WriteString(" {}")
+	} else {
+		out.WriteString(" { ")
+		out.WriteString(strings.Join(fields, ", "))
+		out.WriteString(" }")
+	}
+
+	// Return the string representation
+	return out.String()
+}
+
+// A method of Instance that returns whether it is equal to another object.
+// Instances are equal if they are of the same Struct and have equal fields.
+func (i *Instance) Equals(other Object) bool {
+	// Check that the other object is an Instance of the same Struct
+	otherInstance, ok := other.(*Instance)
+	if !ok || i.Struct != otherInstance.Struct {
+		return false
+	}
+
+	// Compare the fields
+	for _, field := range i.Struct.Fields {
+		if !Equal(i.Fields[field], otherInstance.Fields[field]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -47,15 +47,20 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.registerPrefix(lexer.IDENT, p.parseIdentifier)
 	p.registerPrefix(lexer.INT, p.parseIntegerLiteral)
 	p.registerPrefix(lexer.STRING, p.parseStringLiteral)
+	p.registerPrefix(lexer.STRING_HEAD, p.parseInterpolatedString)
 	p.registerPrefix(lexer.BANG, p.parsePrefixExpression)
 	p.registerPrefix(lexer.MINUS, p.parsePrefixExpression)
 	p.registerPrefix(lexer.TRUE, p.parseBooleanLiteral)
 	p.registerPrefix(lexer.FALSE, p.parseBooleanLiteral)
+	p.registerPrefix(lexer.NULL, p.parseNullLiteral)
 	p.registerPrefix(lexer.LPAREN, p.parseGroupedExpression)
 	p.registerPrefix(lexer.LBRACK, p.parseListLiteral)
 	p.registerPrefix(lexer.LBRACE, p.parseMapLiteral)
 	p.registerPrefix(lexer.IF, p.parseIfExpression)
 	p.registerPrefix(lexer.FUNCTION, p.parseFunctionLiteral)
+	p.registerPrefix(lexer.MACRO, p.parseMacroLiteral)
+	p.registerPrefix(lexer.TRY, p.parseTryExpression)
+	p.registerPrefix(lexer.MATCH, p.parseMatchExpression)
 
 	// Initialize the infix parser function map
 	p.infixParseFns = make(map[lexer.TokenType]InfixParseFn)
@@ -68,8 +73,14 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.registerInfix(lexer.NOT_EQ, p.parseInfixExpression)
 	p.registerInfix(lexer.LT, p.parseInfixExpression)
 	p.registerInfix(lexer.GT, p.parseInfixExpression)
+	p.registerInfix(lexer.LT_EQ, p.parseInfixExpression)
+	p.registerInfix(lexer.GT_EQ, p.parseInfixExpression)
+	p.registerInfix(lexer.IN, p.parseInfixExpression)
+	p.registerInfix(lexer.COALESCE, p.parseInfixExpression)
 	p.registerInfix(lexer.LPAREN, p.parseCallExpression)
 	p.registerInfix(lexer.LBRACK, p.parseIndexExpression)
+	p.registerInfix(lexer.DOT, p.parseMemberExpression)
+	p.registerInfix(lexer.OPTDOT, p.parseOptionalExpression)
 
 	// Advance two tokens such that cursorToken
 	// and peekToken are both set
@@ -2,12 +2,48 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/manishmeganathan/tunalang/lexer"
 	"github.com/manishmeganathan/tunalang/syntaxtree"
 )
 
+func TestConstStatements(t *testing.T) {
+	input := "const answer = 42;"
+
+	l := lexer.NewLexer(input)
+	p := NewParser(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain a statement. got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*syntaxtree.LetStatement)
+	if !ok {
+		t.Fatalf("s not *syntaxtree.LetStatement. got=%T", program.Statements[0])
+	}
+
+	if !stmt.IsConst() {
+		t.Errorf("stmt.IsConst() is false for a const statement")
+	}
+
+	if stmt.Name.Value != "answer" {
+		t.Errorf("stmt.Name.Value not 'answer'. got=%s", stmt.Name.Value)
+	}
+
+	if !testLiteralExpression(t, stmt.Value, 42) {
+		return
+	}
+
+	if program.String() != input {
+		t.Errorf("program.String() wrong. expected=%q, got=%q", input, program.String())
+	}
+}
+
 func TestLetStatements(t *testing.T) {
 	tests := []struct {
 		input              string
@@ -285,6 +321,10 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 		{"a * b * c", "((a * b) * c)"},
 		{"a * b / c", "((a * b) / c)"},
 		{"a + b / c", "(a + (b / c))"},
+		{"a + b <= c * d", "((a + b) <= (c * d))"},
+		{"a >= b == true", "((a >= b) == true)"},
+		{"a + b in c", "((a + b) in c)"},
+		{"a in b == true", "((a in b) == true)"},
 		{"a + b * c + d / e - f", "(((a + (b * c)) + (d / e)) - f)"},
 		{"3 + 4; -5 * 5", "(3 + 4)((-5) * 5)"},
 		{"5 > 4 == 3 < 4", "((5 > 4) == (3 < 4))"},
@@ -314,6 +354,8 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a * b[2], b[1], 2 * [1, 2][1])",
 			"add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))",
 		},
+		{"xs.push(1).len() + 1", "(((xs.push)(1).len)() + 1)"},
+		{`-"abc".upper()`, "(-(abc.upper)())"},
 	}
 
 	for _, tt := range tests {
@@ -687,6 +729,135 @@ func TestParsingIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestParsingSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"xs[1:2]", "(xs[1:2])"},
+		{"xs[:2]", "(xs[:2])"},
+		{"xs[1:]", "(xs[1:])"},
+		{"xs[:]", "(xs[:])"},
+		{"xs[::2]", "(xs[::2])"},
+		{"xs[1:-1:-1]", "(xs[1:(-1):(-1)])"},
+		{"xs[a + 1:b * 2]", "(xs[(a + 1):(b * 2)])"},
+		{"xs[::]", "(xs[:])"},
+		{"xs[1:2][0]", "((xs[1:2])[0])"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*syntaxtree.ExpressionStatement)
+		if !ok {
+			t.Fatalf("statement not syntaxtree.ExpressionStatement. got=%T", program.Statements[0])
+		}
+
+		if _, ok := stmt.Expression.(*syntaxtree.SliceExpression); !ok && tt.input != "xs[1:2][0]" {
+			t.Fatalf("exp not *syntaxtree.SliceExpression. got=%T", stmt.Expression)
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestImportAndExportStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import "lib/math" as math;`, `import "lib/math" as math;`},
+		{`export let x = 5;`, `export let x = 5;`},
+		{`export const y = math.pi;`, `export const y = (math.pi);`},
+		{`export fn sq(x) { x * x }`, `export fn sq(x) (x * x)`},
+		{`export struct Point { x, y }`, `export struct Point { x, y }`},
+		{`export enum Color { Red, Green }`, `export enum Color { Red, Green }`},
+		{`math.square(2) + a.b.c`, `((math.square)(2) + ((a.b).c))`},
+		{`lists.all[0]`, `((lists.all)[0])`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []string{
+		`import lib as lib;`,
+		`import "lib";`,
+		`import "lib" as "x";`,
+		`export 5;`,
+		`export fn(x) { x };`,
+		`export return 5;`,
+		`a.5`,
+	}
+
+	for _, input := range errorTests {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+func TestParsingInterpolatedStrings(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    string
+		expressions int
+	}{
+		{`"Hello ${name}!"`, `"Hello ${name}!"`, 1},
+		{`"${a + 1} and ${f(b)}"`, `"${(a + 1)} and ${f(b)}"`, 2},
+		{`"outer ${"inner ${x}"}"`, `"outer ${"inner ${x}"}"`, 1},
+		{`"${ {"a": 1}["a"] }"`, `"${({a:1}[a])}"`, 1},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*syntaxtree.ExpressionStatement)
+		str, ok := stmt.Expression.(*syntaxtree.InterpolatedString)
+		if !ok {
+			t.Fatalf("exp not *syntaxtree.InterpolatedString. got=%T", stmt.Expression)
+		}
+
+		if len(str.Expressions) != tt.expressions || len(str.Texts) != tt.expressions+1 {
+			t.Errorf("wrong number of parts for %q. got texts=%d expressions=%d", tt.input, len(str.Texts), len(str.Expressions))
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	for _, input := range []string{`"${}"`, `"${a b}"`, `"${a`} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
 func TestParsingEmptyMapLiteral(t *testing.T) {
 	input := "{}"
 
@@ -937,3 +1108,443 @@ func checkParserErrors(t *testing.T, p *Parser) {
 
 	t.FailNow()
 }
+
+func TestTryExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input      string
+		hasCatch   bool
+		hasFinally bool
+		expected   string
+	}{
+		{`try { x } catch (e) { y }`, true, false, "try x catch (e) y"},
+		{`try { x } finally { z }`, false, true, "try x finally z"},
+		{`try { x } catch (err) { y } finally { z }`, true, true, "try x catch (err) y finally z"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*syntaxtree.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not syntaxtree.ExpressionStatement. got=%T", program.Statements[0])
+		}
+
+		exp, ok := stmt.Expression.(*syntaxtree.TryExpression)
+		if !ok {
+			t.Fatalf("stmt.Expression is not syntaxtree.TryExpression. got=%T", stmt.Expression)
+		}
+
+		if (exp.Catch != nil) != tt.hasCatch || (exp.Finally != nil) != tt.hasFinally {
+			t.Errorf("wrong blocks for %q. catch=%t, finally=%t", tt.input, exp.Catch != nil, exp.Finally != nil)
+		}
+
+		if exp.String() != tt.expected {
+			t.Errorf("exp.String() wrong. expected=%q, got=%q", tt.expected, exp.String())
+		}
+	}
+
+	for _, input := range []string{`try { x }`, `try { x } catch { y }`, `try { x } catch (1) { y }`} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+func TestThrowStatements(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedValue interface{}
+	}{
+		{"throw 5;", 5},
+		{"throw err;", "err"},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(lexer.NewLexer(tt.input))
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*syntaxtree.ThrowStatement)
+		if !ok {
+			t.Fatalf("stmt not *syntaxtree.ThrowStatement. got=%T", program.Statements[0])
+		}
+
+		testLiteralExpression(t, stmt.Value, tt.expectedValue)
+	}
+}
+
+func TestParsingMatchExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		arms     int
+	}{
+		{`match (x) { 1 => "one", _ => "other" }`, `match (x) { 1 => one, _ => other }`, 2},
+		{`match (x) { -1 => a, "s" => b, true => c }`, `match (x) { (-1) => a, "s" => b, true => c }`, 3},
+		{`match (x) { n if n > 1 => n }`, `match (x) { n if (n > 1) => n }`, 1},
+		{`match (x) { [a, [b], ...rest] => a, [1, ...] => b, [] => c }`, `match (x) { [a, [b], ...rest] => a, [1, ...] => b, [] => c }`, 3},
+		{`match (x) { {"k": v, 1: _} => v, {} => 0, }`, `match (x) { {"k": v, 1: _} => v, {} => 0 }`, 2},
+		{`match (f(x)) { y => { let z = y; z } }`, `match (f(x)) { y => let z = y;z }`, 1},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*syntaxtree.ExpressionStatement)
+		match, ok := stmt.Expression.(*syntaxtree.MatchExpression)
+		if !ok {
+			t.Fatalf("exp not *syntaxtree.MatchExpression. got=%T", stmt.Expression)
+		}
+
+		if len(match.Arms) != tt.arms {
+			t.Errorf("wrong number of arms for %q. expected=%d, got=%d", tt.input, tt.arms, len(match.Arms))
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	for _, input := range []string{
+		`match (x) { }`,
+		`match (x) { 1 "one" }`,
+		`match (x) { [...rest, a] => a }`,
+		`match (x) { {k: v} => v }`,
+		`match (x) { f(y) => y }`,
+		`match (x) { 1 => a 2 => b }`,
+		`match x { 1 => a }`,
+	} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+func TestParsingDestructuringLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		names    []string
+	}{
+		{`let [a, b, ...rest] = xs;`, `let [a, b, ...rest] = xs;`, []string{"a", "b", "rest"}},
+		{`let [_, [b], c = 1 + 2, ...] = xs;`, `let [_, [b], c = (1 + 2), ...] = xs;`, []string{"b", "c"}},
+		{`let {name, age: years} = person;`, `let {"name": name, "age": years} = person;`, []string{"name", "years"}},
+		{`const {"a b": x = 1, c: [d]} = m;`, `const {"a b": x = 1, "c": [d]} = m;`, []string{"x", "d"}},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*syntaxtree.LetStatement)
+		if !ok {
+			t.Fatalf("stmt not *syntaxtree.LetStatement. got=%T", program.Statements[0])
+		}
+
+		if stmt.Pattern == nil || stmt.Name != nil {
+			t.Errorf("let statement does not destructure for %q", tt.input)
+		}
+
+		names := []string{}
+		for _, name := range stmt.Names() {
+			names = append(names, name.Value)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.names, ",") {
+			t.Errorf("wrong names for %q. expected=%v, got=%v", tt.input, tt.names, names)
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	for _, input := range []string{
+		`let [1, a] = xs;`,
+		`let [...rest = 1] = xs;`,
+		`let {1: a} = m;`,
+		`let {a: } = m;`,
+		`let [a, b] xs;`,
+		`let (a) = 1;`,
+	} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+func TestStructStatements(t *testing.T) {
+	input := `struct Point {
+		x, y;
+		fn sum() { self.x + self.y }
+		fn scale(k) { Point(self.x * k, self.y * k) },
+	}`
+
+	l := lexer.NewLexer(input)
+	p := NewParser(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*syntaxtree.StructStatement)
+	if !ok {
+		t.Fatalf("stmt not *syntaxtree.StructStatement. got=%T", program.Statements[0])
+	}
+
+	if stmt.Name.Value != "Point" || len(stmt.Fields) != 2 || len(stmt.Methods) != 2 {
+		t.Fatalf("wrong struct declaration. got name=%s fields=%d methods=%d", stmt.Name.Value, len(stmt.Fields), len(stmt.Methods))
+	}
+
+	expected := "struct Point { x, y, fn sum() ((self.x) + (self.y)), fn scale(k) Point(((self.x) * k), ((self.y) * k)) }"
+	if stmt.String() != expected {
+		t.Errorf("expected=%q, got=%q", expected, stmt.String())
+	}
+
+	for _, input := range []string{
+		`struct { x }`,
+		`struct Point x, y`,
+		`struct Point { x, x }`,
+		`struct Point { x, fn x() { 1 } }`,
+		`struct Point { 1 }`,
+		`struct Point { fn () { 1 } }`,
+		`struct Point { x`,
+	} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+func TestEnumStatements(t *testing.T) {
+	input := `enum Shape { Circle(r), Rect(w, h), Empty, }`
+
+	l := lexer.NewLexer(input)
+	p := NewParser(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*syntaxtree.EnumStatement)
+	if !ok {
+		t.Fatalf("stmt not *syntaxtree.EnumStatement. got=%T", program.Statements[0])
+	}
+
+	if stmt.Name.Value != "Shape" || len(stmt.Variants) != 3 {
+		t.Fatalf("wrong enum declaration. got name=%s variants=%d", stmt.Name.Value, len(stmt.Variants))
+	}
+
+	expected := "enum Shape { Circle(r), Rect(w, h), Empty }"
+	if stmt.String() != expected {
+		t.Errorf("expected=%q, got=%q", expected, stmt.String())
+	}
+
+	for _, input := range []string{
+		`enum { A }`,
+		`enum Shape { A, A }`,
+		`enum Shape { A() }`,
+		`enum Shape { A B }`,
+		`enum Shape { 1 }`,
+		`enum Shape { A(x }`,
+	} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+func TestFunctionStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		name     string
+		params   int
+	}{
+		{`fn add(a, b) { a + b }`, "fn add(a, b) (a + b)", "add", 2},
+		{`fn noop() {};`, "fn noop() ", "noop", 0},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*syntaxtree.FunctionStatement)
+		if !ok {
+			t.Fatalf("stmt not *syntaxtree.FunctionStatement. got=%T", program.Statements[0])
+		}
+
+		if stmt.Name.Value != tt.name || len(stmt.Function.Parameters) != tt.params {
+			t.Errorf("wrong function declaration. got name=%s params=%d", stmt.Name.Value, len(stmt.Function.Parameters))
+		}
+
+		if stmt.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, stmt.String())
+		}
+	}
+
+	// Anonymous function literals remain expression statements
+	p := NewParser(lexer.NewLexer(`fn(x) { x }(1)`))
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if _, ok := program.Statements[0].(*syntaxtree.ExpressionStatement); !ok {
+		t.Errorf("stmt not *syntaxtree.ExpressionStatement. got=%T", program.Statements[0])
+	}
+
+	for _, input := range []string{`fn add { 1 }`, `fn add(a, b)`, `fn 1() {}`} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+func TestMacroLiteralParsing(t *testing.T) {
+	input := `macro(x, y) { x + y; }`
+
+	l := lexer.NewLexer(input)
+	p := NewParser(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*syntaxtree.ExpressionStatement)
+	if !ok {
+		t.Fatalf("stmt not *syntaxtree.ExpressionStatement. got=%T", program.Statements[0])
+	}
+
+	macro, ok := stmt.Expression.(*syntaxtree.MacroLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression not *syntaxtree.MacroLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(macro.Parameters) != 2 {
+		t.Fatalf("macro literal parameters wrong. want 2, got=%d", len(macro.Parameters))
+	}
+
+	testLiteralExpression(t, macro.Parameters[0], "x")
+	testLiteralExpression(t, macro.Parameters[1], "y")
+
+	if len(macro.Body.Statements) != 1 {
+		t.Fatalf("macro.Body.Statements has not 1 statement. got=%d", len(macro.Body.Statements))
+	}
+
+	bodyStmt, ok := macro.Body.Statements[0].(*syntaxtree.ExpressionStatement)
+	if !ok {
+		t.Fatalf("macro body stmt is not *syntaxtree.ExpressionStatement. got=%T", macro.Body.Statements[0])
+	}
+
+	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+
+	if macro.String() != "macro(x, y) (x + y)" {
+		t.Errorf("macro.String() wrong. got=%q", macro.String())
+	}
+
+	for _, input := range []string{`macro x { x }`, `macro(x)`, `macro(x { x }`} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
+
+func TestNullSafeExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"null", "null"},
+		{"a ?? b", "(a ?? b)"},
+		{"a ?? b ?? c", "((a ?? b) ?? c)"},
+		{"a ?? b == c", "(a ?? (b == c))"},
+		{"a?.[k]", "(a?.[k])"},
+		{"a?.b", "(a?.b)"},
+		{"a?.b.c", "((a?.b).c)"},
+		{"a?.[1:2]", "(a?.[1:2])"},
+		{"a?.b?.[0] ?? -1", "(((a?.b)?.[0]) ?? (-1))"},
+		{"(a?.b).c", "(((a?.b)).c)"},
+		{"(a?.b.c())[0]", "((((a?.b).c)())[0])"},
+		{"(a.b).c", "((a.b).c)"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		actual := program.String()
+		if actual != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, actual)
+		}
+	}
+
+	// Check the optional flag of the parsed expressions
+	program := NewParser(lexer.NewLexer("a?.[k]; a?.b; a?.[:1]; a[k]")).ParseProgram()
+	optionals := []bool{
+		program.Statements[0].(*syntaxtree.ExpressionStatement).Expression.(*syntaxtree.IndexExpression).Optional,
+		program.Statements[1].(*syntaxtree.ExpressionStatement).Expression.(*syntaxtree.MemberExpression).Optional,
+		program.Statements[2].(*syntaxtree.ExpressionStatement).Expression.(*syntaxtree.SliceExpression).Optional,
+		program.Statements[3].(*syntaxtree.ExpressionStatement).Expression.(*syntaxtree.IndexExpression).Optional,
+	}
+	for idx, expected := range []bool{true, true, true, false} {
+		if optionals[idx] != expected {
+			t.Errorf("statement %d has wrong optional flag. expected=%t, got=%t", idx, expected, optionals[idx])
+		}
+	}
+
+	for _, input := range []string{`a?.1`, `a?.(b)`, `a ??`} {
+		p := NewParser(lexer.NewLexer(input))
+		p.ParseProgram()
+
+		if len(p.Errors) == 0 {
+			t.Errorf("expected parser errors for %q", input)
+		}
+	}
+}
@@ -11,26 +11,33 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	COALESCE    // ??
 	EQUALS      // ==
-	LESSGREATER // > or <
+	LESSGREATER // > or < or >= or <= or in
 	SUM         // +
 	PRODUCT     // *
 	PREFIX      // -X or !X
 	CALL        // myFunction(X)
-	INDEX       // myList[X]
+	INDEX       // myList[X] or myModule.X or myMap?.X
 )
 
 var precedences = map[lexer.TokenType]int{
+	lexer.COALESCE: COALESCE,
 	lexer.EQ:       EQUALS,
 	lexer.NOT_EQ:   EQUALS,
 	lexer.LT:       LESSGREATER,
 	lexer.GT:       LESSGREATER,
+	lexer.LT_EQ:    LESSGREATER,
+	lexer.GT_EQ:    LESSGREATER,
+	lexer.IN:       LESSGREATER,
 	lexer.PLUS:     SUM,
 	lexer.MINUS:    SUM,
 	lexer.SLASH:    PRODUCT,
 	lexer.ASTERISK: PRODUCT,
 	lexer.LPAREN:   CALL,
 	lexer.LBRACK:   INDEX,
+	lexer.DOT:      INDEX,
+	lexer.OPTDOT:   INDEX,
 }
 
 var traceON = false
@@ -75,16 +82,54 @@ func (p *Parser) parseStatement() syntaxtree.Statement {
 	// Check the value of the token in the parse cursor
 	switch p.cursorToken.Type {
 
-	// Let Statement
-	case lexer.LET:
+	// Let or Const Statement
+	case lexer.LET, lexer.CONST:
 		// Parse the statement into a 'let' statement
 		return p.parseLetStatement()
 
+	// Function Statement (a function literal is an expression statement)
+	case lexer.FUNCTION:
+		// Check if the function is named
+		if !p.isPeekToken(lexer.IDENT) {
+			return p.parseExpressionStatement()
+		}
+
+		// Parse the statement into a 'fn' statement
+		if stmt := p.parseFunctionStatement(); stmt != nil {
+			return stmt
+		}
+		return nil
+
 	// Return Statement
 	case lexer.RETURN:
 		// Parse the statement into a 'return' statement
 		return p.parseReturnStatement()
 
+	// Throw Statement
+	case lexer.THROW:
+		// Parse the statement into a 'throw' statement
+		return p.parseThrowStatement()
+
+	// Import Statement
+	case lexer.IMPORT:
+		// Parse the statement into an 'import' statement
+		return p.parseImportStatement()
+
+	// Export Statement
+	case lexer.EXPORT:
+		// Parse the statement into an 'export' statement
+		return p.parseExportStatement()
+
+	// Struct Statement
+	case lexer.STRUCT:
+		// Parse the statement into a 'struct' statement
+		return p.parseStructStatement()
+
+	// Enum Statement
+	case lexer.ENUM:
+		// Parse the statement into an 'enum' statement
+		return p.parseEnumStatement()
+
 	// Expression Statement
 	default:
 		// Parse the statement into an 'expression' statement
@@ -92,20 +137,30 @@ func (p *Parser) parseStatement() syntaxtree.Statement {
 	}
 }
 
-// A method of Parser that parses the token in the parse
-// cursor into a LET statement node for the syntax tree
+// A method of Parser that parses the token in the parse cursor
+// into a LET (or CONST) statement node for the syntax tree
 func (p *Parser) parseLetStatement() *syntaxtree.LetStatement {
 	// Create a LET statement node with the token
 	stmt := &syntaxtree.LetStatement{Token: p.cursorToken}
 
-	// Check the peek cursor for an identfier token and move to it
-	if !p.expectPeek(lexer.IDENT) {
+	// Check the peek cursor for a destructuring pattern
+	if p.isPeekToken(lexer.LBRACK) || p.isPeekToken(lexer.LBRACE) {
+		// Advance the parse cursor
+		p.NextToken()
+		// Assign the parsed destructuring pattern to the statement node
+		if stmt.Pattern = p.parseDestructuringPattern(); stmt.Pattern == nil {
+			return nil
+		}
+
+		// Check the peek cursor for an identfier token and move to it
+	} else if !p.expectPeek(lexer.IDENT) {
 		// no identifier token detected i.e invalid let statement
 		return nil
-	}
 
-	// Assign the statement identifier to the statement node
-	stmt.Name = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+	} else {
+		// Assign the statement identifier to the statement node
+		stmt.Name = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+	}
 
 	// Check the peek cursor for assignment token and move to it
 	if !p.expectPeek(lexer.ASSIGN) {
@@ -128,6 +183,273 @@ func (p *Parser) parseLetStatement() *syntaxtree.LetStatement {
 	return stmt
 }
 
+// A method of Parser that parses the token in the parse
+// cursor into an IMPORT statement node for the syntax tree
+func (p *Parser) parseImportStatement() syntaxtree.Statement {
+	// Create an IMPORT statement node with the token
+	stmt := &syntaxtree.ImportStatement{Token: p.cursorToken}
+
+	// Check the peek cursor for the path of the module and move to it
+	if !p.expectPeek(lexer.STRING) {
+		return nil
+	}
+
+	// Assign the path of the module to the statement node
+	stmt.Path = &syntaxtree.StringLiteral{Token: p.cursorToken, Value: p.cursorToken.Literal}
+
+	// Check the peek cursor for the 'as' keyword and the module name
+	if !p.expectPeek(lexer.AS) || !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+
+	// Assign the module name to the statement node
+	stmt.Name = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+
+	// Advance until semicolon in encountered
+	if p.isPeekToken(lexer.SEMICOLON) {
+		p.NextToken()
+	}
+
+	// Return the parsed import statement
+	return stmt
+}
+
+// A method of Parser that parses the token in the parse
+// cursor into an EXPORT statement node for the syntax tree
+func (p *Parser) parseExportStatement() syntaxtree.Statement {
+	// Create an EXPORT statement node with the token
+	stmt := &syntaxtree.ExportStatement{Token: p.cursorToken}
+
+	// Check that a declaration is exported
+	switch p.peekToken.Type {
+	case lexer.LET, lexer.CONST, lexer.FUNCTION, lexer.STRUCT, lexer.ENUM:
+	default:
+		p.Errors = append(p.Errors, fmt.Sprintf("expected declaration after export, got %s instead", p.peekToken.Type))
+		return nil
+	}
+
+	// Advance the parse cursor to the declaration
+	p.NextToken()
+
+	// Check that an exported function is named
+	if p.isCursorToken(lexer.FUNCTION) && !p.isPeekToken(lexer.IDENT) {
+		p.Errors = append(p.Errors, fmt.Sprintf("expected function name after export fn, got %s instead", p.peekToken.Type))
+		return nil
+	}
+
+	// Parse the exported declaration
+	switch p.cursorToken.Type {
+	case lexer.LET, lexer.CONST:
+		if declaration := p.parseLetStatement(); declaration != nil {
+			stmt.Declaration = declaration
+		}
+	case lexer.FUNCTION:
+		if declaration := p.parseFunctionStatement(); declaration != nil {
+			stmt.Declaration = declaration
+		}
+	case lexer.STRUCT:
+		if declaration, ok := p.parseStructStatement().(syntaxtree.Declaration); ok {
+			stmt.Declaration = declaration
+		}
+	case lexer.ENUM:
+		if declaration, ok := p.parseEnumStatement().(syntaxtree.Declaration); ok {
+			stmt.Declaration = declaration
+		}
+	}
+
+	// Check if the declaration could not be parsed
+	if stmt.Declaration == nil {
+		return nil
+	}
+
+	// Return the parsed export statement
+	return stmt
+}
+
+// A method of Parser that parses the token in the parse cursor
+// into a named FN statement node for the syntax tree
+func (p *Parser) parseFunctionStatement() *syntaxtree.FunctionStatement {
+	// Create a FN statement node with the token
+	stmt := &syntaxtree.FunctionStatement{Token: p.cursorToken}
+
+	// Check the peek cursor for the name of the function and move to it
+	if !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+
+	// Assign the name of the function to the statement node
+	stmt.Name = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+
+	// Parse the parameters and the body of the function
+	function, ok := p.parseFunctionLiteral().(*syntaxtree.FunctionLiteral)
+	if !ok {
+		return nil
+	}
+	function.Token = stmt.Token
+	stmt.Function = function
+
+	// Advance until semicolon in encountered
+	if p.isPeekToken(lexer.SEMICOLON) {
+		// Advance the parse cursor
+		p.NextToken()
+	}
+
+	// Return the parsed function statement
+	return stmt
+}
+
+// A method of Parser that parses the token in the parse cursor into a STRUCT statement node
+// for the syntax tree. The members of a struct are field names and methods (fn name(params)
+// { body }), which may be separated by commas or semicolons.
+func (p *Parser) parseStructStatement() syntaxtree.Statement {
+	// Create a STRUCT statement node with the token
+	stmt := &syntaxtree.StructStatement{Token: p.cursorToken}
+
+	// Check the peek cursor for the name of the struct and move to it
+	if !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+
+	// Assign the name of the struct to the statement node
+	stmt.Name = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+
+	// Check for the members opening { token
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+
+	// Track the names of the members to detect duplicates
+	members := make(map[string]bool)
+
+	// Iterate until the next token is the } token
+	for !p.isPeekToken(lexer.RBRACE) {
+		// Advance the parse cursor to the member
+		p.NextToken()
+
+		// Check the type of the member
+		var name *syntaxtree.Identifier
+		switch p.cursorToken.Type {
+
+		// Field
+		case lexer.IDENT:
+			name = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+			stmt.Fields = append(stmt.Fields, name)
+
+		// Method
+		case lexer.FUNCTION:
+			// Parse the method as a named function
+			method := p.parseFunctionStatement()
+			if method == nil {
+				return nil
+			}
+
+			name = method.Name
+			stmt.Methods = append(stmt.Methods, method)
+
+		default:
+			// Add the error to the parser's errors
+			p.Errors = append(p.Errors, fmt.Sprintf("expected field or method in struct %s, got %s instead", stmt.Name.Value, p.cursorToken.Type))
+			return nil
+		}
+
+		// Check that the member is not a duplicate
+		if members[name.Value] {
+			p.Errors = append(p.Errors, fmt.Sprintf("duplicate member in struct %s: %s", stmt.Name.Value, name.Value))
+			return nil
+		}
+		members[name.Value] = true
+
+		// Skip the separator after the member
+		if p.isPeekToken(lexer.COMMA) || p.isPeekToken(lexer.SEMICOLON) {
+			p.NextToken()
+		}
+	}
+
+	// Check for the members ending } token
+	if !p.expectPeek(lexer.RBRACE) {
+		return nil
+	}
+
+	// Advance until semicolon in encountered
+	if p.isPeekToken(lexer.SEMICOLON) {
+		// Advance the parse cursor
+		p.NextToken()
+	}
+
+	// Return the parsed struct statement
+	return stmt
+}
+
+// A method of Parser that parses the token in the parse cursor into an ENUM statement node
+// for the syntax tree. The variants of an enum are separated by commas and are either a
+// name or a name followed by the parenthesized names of its payload fields.
+func (p *Parser) parseEnumStatement() syntaxtree.Statement {
+	// Create an ENUM statement node with the token
+	stmt := &syntaxtree.EnumStatement{Token: p.cursorToken}
+
+	// Check the peek cursor for the name of the enum and move to it
+	if !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+
+	// Assign the name of the enum to the statement node
+	stmt.Name = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+
+	// Check for the variants opening { token
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+
+	// Track the names of the variants to detect duplicates
+	names := make(map[string]bool)
+
+	// Iterate until the next token is the } token
+	for !p.isPeekToken(lexer.RBRACE) {
+		// Check the peek cursor for the name of the variant and move to it
+		if !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		variant := &syntaxtree.EnumVariant{Name: &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}}
+
+		// Check that the variant is not a duplicate
+		if names[variant.Name.Value] {
+			p.Errors = append(p.Errors, fmt.Sprintf("duplicate variant in enum %s: %s", stmt.Name.Value, variant.Name.Value))
+			return nil
+		}
+		names[variant.Name.Value] = true
+
+		// Check for the payload fields of the variant
+		if p.isPeekToken(lexer.LPAREN) {
+			p.NextToken()
+			if variant.Fields = p.parseFunctionParameters(); len(variant.Fields) == 0 {
+				p.Errors = append(p.Errors, fmt.Sprintf("expected payload fields for variant %s.%s", stmt.Name.Value, variant.Name.Value))
+				return nil
+			}
+		}
+
+		stmt.Variants = append(stmt.Variants, variant)
+
+		// Check for the , token between variants
+		if !p.isPeekToken(lexer.RBRACE) && !p.expectPeek(lexer.COMMA) {
+			return nil
+		}
+	}
+
+	// Check for the variants ending } token
+	if !p.expectPeek(lexer.RBRACE) {
+		return nil
+	}
+
+	// Advance until semicolon in encountered
+	if p.isPeekToken(lexer.SEMICOLON) {
+		// Advance the parse cursor
+		p.NextToken()
+	}
+
+	// Return the parsed enum statement
+	return stmt
+}
+
 // A method of Parser that parses the token in the parse
 // cursor into a RETURN statement node for the syntax tree
 func (p *Parser) parseReturnStatement() *syntaxtree.ReturnStatement {
@@ -148,6 +470,26 @@ func (p *Parser) parseReturnStatement() *syntaxtree.ReturnStatement {
 	return stmt
 }
 
+// A method of Parser that parses the token in the parse
+// cursor into a THROW statement node for the syntax tree
+func (p *Parser) parseThrowStatement() *syntaxtree.ThrowStatement {
+	// Create a THROW statement node with the token
+	stmt := &syntaxtree.ThrowStatement{Token: p.cursorToken}
+	// Advance the parse cursor
+	p.NextToken()
+
+	// Assign the parsed thrown value
+	stmt.Value = p.parseExpression(LOWEST)
+
+	// Advance until semicolon in encountered
+	if p.isPeekToken(lexer.SEMICOLON) {
+		p.NextToken()
+	}
+
+	// Return the parsed throw statement
+	return stmt
+}
+
 // A method of Parser that parses the token in the parse
 // cursor into an expression statement node for the syntax tree
 func (p *Parser) parseExpressionStatement() *syntaxtree.ExpressionStatement {
@@ -296,13 +638,51 @@ func (p *Parser) parseBooleanLiteral() syntaxtree.Expression {
 	return &syntaxtree.BooleanLiteral{Token: p.cursorToken, Value: p.isCursorToken(lexer.TRUE)}
 }
 
+// A method of Parser that parses a Null Literal
+func (p *Parser) parseNullLiteral() syntaxtree.Expression {
+	return &syntaxtree.NullLiteral{Token: p.cursorToken}
+}
+
 // A method of Parser that parses a String Literal
 func (p *Parser) parseStringLiteral() syntaxtree.Expression {
 	return &syntaxtree.StringLiteral{Token: p.cursorToken, Value: p.cursorToken.Literal}
 }
 
-// A method of Parser that parses Grouped Expressions
+// A method of Parser that parses interpolated String literals
+func (p *Parser) parseInterpolatedString() syntaxtree.Expression {
+	// Create an interpolated string node with the text before the first expression
+	str := &syntaxtree.InterpolatedString{Token: p.cursorToken, Texts: []string{p.cursorToken.Literal}}
+
+	// Iterate until the text after the last expression is parsed
+	for {
+		// Advance the parse cursor
+		p.NextToken()
+		// Parse the embedded expression
+		str.Expressions = append(str.Expressions, p.parseExpression(LOWEST))
+
+		// Check if more text and expressions follow
+		if p.isPeekToken(lexer.STRING_MIDDLE) {
+			p.NextToken()
+			str.Texts = append(str.Texts, p.cursorToken.Literal)
+			continue
+		}
+
+		// Check for the text at the end of the string
+		if !p.expectPeek(lexer.STRING_TAIL) {
+			return nil
+		}
+
+		str.Texts = append(str.Texts, p.cursorToken.Literal)
+		// Return the parsed interpolated string
+		return str
+	}
+}
+
+// A method of Parser that parses Grouped Expressions. The parentheses are kept as a
+// node only around an optional chain, which they end, and are otherwise discarded.
 func (p *Parser) parseGroupedExpression() syntaxtree.Expression {
+	// Retrieve the ( token
+	token := p.cursorToken
 	// Advance the parse cursor
 	p.NextToken()
 	// Parse the expression in the parentheses
@@ -313,10 +693,33 @@ func (p *Parser) parseGroupedExpression() syntaxtree.Expression {
 		return nil
 	}
 
+	// Check if the expression is an optional chain
+	if isOptionalChain(exp) {
+		return &syntaxtree.GroupedExpression{Token: token, Expression: exp}
+	}
+
 	// Return the parsed parentheses
 	return exp
 }
 
+// A function that returns whether an expression is an optional chain, which is
+// an access or call that contains an optional access (?.) in its operands
+func isOptionalChain(exp syntaxtree.Expression) bool {
+	// Check the type of the expression
+	switch exp := exp.(type) {
+	case *syntaxtree.IndexExpression:
+		return exp.Optional || isOptionalChain(exp.Left)
+	case *syntaxtree.SliceExpression:
+		return exp.Optional || isOptionalChain(exp.Left)
+	case *syntaxtree.MemberExpression:
+		return exp.Optional || isOptionalChain(exp.Object)
+	case *syntaxtree.CallExpression:
+		return isOptionalChain(exp.Function)
+	}
+
+	return false
+}
+
 // A method of Parser that parses Block Statements
 func (p *Parser) parseBlockStatement() *syntaxtree.BlockStatement {
 	// Create a block statement node for the syntax tree
@@ -446,6 +849,29 @@ func (p *Parser) parseFunctionLiteral() syntaxtree.Expression {
 	return lit
 }
 
+// A method of Parser that parses a macro literal
+func (p *Parser) parseMacroLiteral() syntaxtree.Expression {
+	// Create a macro literal node
+	lit := &syntaxtree.MacroLiteral{Token: p.cursorToken}
+
+	// Check for the macro parameter begin ( token
+	if !p.expectPeek(lexer.LPAREN) {
+		return nil
+	}
+	// Assign the macro parameters after parsing them
+	lit.Parameters = p.parseFunctionParameters()
+
+	// Check for the macro block begin { token
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	// Assign the macro body after parsing it
+	lit.Body = p.parseBlockStatement()
+
+	// Return the parsed macro literal node
+	return lit
+}
+
 // A method of Parser that parses a list of expressions
 func (p *Parser) parseExpressionList(end lexer.TokenType) []syntaxtree.Expression {
 	// Initialize a slice of expression nodes
@@ -507,19 +933,130 @@ func (p *Parser) parseListLiteral() syntaxtree.Expression {
 
 // A method of Parser that parses Index expressions
 func (p *Parser) parseIndexExpression(left syntaxtree.Expression) syntaxtree.Expression {
-	// Create an index expression node for the syntax tree
-	exp := &syntaxtree.IndexExpression{Token: p.cursorToken, Left: left}
+	// Retrieve the [ token
+	token := p.cursorToken
+
+	// Declare the expression for the index (or the start of a slice)
+	var index syntaxtree.Expression
+	// Check if the index is omitted (a slice without a start)
+	if !p.isPeekToken(lexer.COLON) {
+		// Advance the parse cursor
+		p.NextToken()
+		// Parse the expression for the index
+		index = p.parseExpression(LOWEST)
+	}
+
+	// Check if the expression is a slice
+	if p.isPeekToken(lexer.COLON) {
+		// Parse the remainder of the slice expression
+		return p.parseSliceExpression(token, left, index)
+	}
 
-	// Advance the parse cursor
-	p.NextToken()
-	// Parse the expression for the index
-	exp.Index = p.parseExpression(LOWEST)
 	// Check for the ] token
 	if !p.expectPeek(lexer.RBRACK) {
 		return nil
 	}
 
 	// Return the parsed index expression
+	return &syntaxtree.IndexExpression{Token: token, Left: left, Index: index}
+}
+
+// A method of Parser that parses a member access expression given the accessed expression
+func (p *Parser) parseMemberExpression(object syntaxtree.Expression) syntaxtree.Expression {
+	// Create a member expression node for the syntax tree
+	exp := &syntaxtree.MemberExpression{Token: p.cursorToken, Object: object}
+
+	// Check for the identifier of the member
+	if !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+
+	// Assign the member name to the expression node
+	exp.Property = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+
+	// Return the parsed member expression
+	return exp
+}
+
+// A method of Parser that parses an optional member access (?.name) or an optional index or slice
+// expression (?.[index]) given the accessed expression. The accesses and calls that follow it are
+// parsed as usual and form an optional chain, which is skipped when the accessed expression is null.
+func (p *Parser) parseOptionalExpression(left syntaxtree.Expression) syntaxtree.Expression {
+	// Check the token after the ?. token
+	switch {
+
+	// Optional Index or Slice Expression
+	case p.isPeekToken(lexer.LBRACK):
+		// Advance the parse cursor to the [ token
+		p.NextToken()
+
+		// Parse the index or slice expression and mark it as optional
+		switch exp := p.parseIndexExpression(left).(type) {
+		case *syntaxtree.IndexExpression:
+			exp.Optional = true
+			return exp
+		case *syntaxtree.SliceExpression:
+			exp.Optional = true
+			return exp
+		}
+
+		return nil
+
+	// Optional Member Expression
+	case p.isPeekToken(lexer.IDENT):
+		// Parse the member expression and mark it as optional
+		exp, ok := p.parseMemberExpression(left).(*syntaxtree.MemberExpression)
+		if !ok {
+			return nil
+		}
+
+		exp.Optional = true
+		return exp
+
+	default:
+		// Add the error to the parser's errors
+		p.Errors = append(p.Errors, fmt.Sprintf("expected next token to be [ or IDENT after ?., got %s instead", p.peekToken.Type))
+		return nil
+	}
+}
+
+// A method of Parser that parses the end and step of a slice expression
+// given the [ token, the sliced expression and the parsed start of the slice
+func (p *Parser) parseSliceExpression(token lexer.Token, left, start syntaxtree.Expression) syntaxtree.Expression {
+	// Create a slice expression node for the syntax tree
+	exp := &syntaxtree.SliceExpression{Token: token, Left: left, Start: start}
+
+	// Advance the parse cursor to the : token
+	p.NextToken()
+
+	// Check if the end of the slice exists
+	if !p.isPeekToken(lexer.COLON) && !p.isPeekToken(lexer.RBRACK) {
+		// Advance the parse cursor
+		p.NextToken()
+		// Parse the expression for the end
+		exp.End = p.parseExpression(LOWEST)
+	}
+
+	// Check if the step of the slice exists
+	if p.isPeekToken(lexer.COLON) {
+		// Advance the parse cursor to the : token
+		p.NextToken()
+
+		// Check if the step is not omitted
+		if !p.isPeekToken(lexer.RBRACK) {
+			// Advance the parse cursor
+			p.NextToken()
+			// Parse the expression for the step
+			exp.Step = p.parseExpression(LOWEST)
+		}
+	}
+
+	// Check for the ] token
+	if !p.expectPeek(lexer.RBRACK) {
+		return nil
+	}
+
+	// Return the parsed slice expression
 	return exp
 }
 
@@ -562,3 +1099,61 @@ func (p *Parser) parseMapLiteral() syntaxtree.Expression {
 	// Return the parsed map literal
 	return hash
 }
+
+// A method of Parser that parses Try expressions
+func (p *Parser) parseTryExpression() syntaxtree.Expression {
+	// Create a try expression node for the syntax tree
+	expression := &syntaxtree.TryExpression{Token: p.cursorToken}
+
+	// Check for the block opening { token
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	// Parse the tried block statement
+	expression.Block = p.parseBlockStatement()
+
+	// Check the CATCH token
+	if p.isPeekToken(lexer.CATCH) {
+		// Advance the parse cursor
+		p.NextToken()
+
+		// Check for the catch parameter between ( and ) tokens
+		if !p.expectPeek(lexer.LPAREN) || !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		expression.Parameter = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+		if !p.expectPeek(lexer.RPAREN) {
+			return nil
+		}
+
+		// Check for the block opening { token
+		if !p.expectPeek(lexer.LBRACE) {
+			return nil
+		}
+		// Parse the catch block statement
+		expression.Catch = p.parseBlockStatement()
+	}
+
+	// Check the FINALLY token
+	if p.isPeekToken(lexer.FINALLY) {
+		// Advance the parse cursor
+		p.NextToken()
+
+		// Check for the block opening { token
+		if !p.expectPeek(lexer.LBRACE) {
+			return nil
+		}
+		// Parse the finally block statement
+		expression.Finally = p.parseBlockStatement()
+	}
+
+	// Check that the try expression has a catch or a finally block
+	if expression.Catch == nil && expression.Finally == nil {
+		// Add the error to parser's errors
+		p.Errors = append(p.Errors, "expected catch or finally block after try block")
+		return nil
+	}
+
+	// Return the parsed try expression
+	return expression
+}
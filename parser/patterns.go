@@ -0,0 +1,395 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/manishmeganathan/tunalang/lexer"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// A method of Parser that parses match expressions
+func (p *Parser) parseMatchExpression() syntaxtree.Expression {
+	// Create a match expression node for the syntax tree
+	expression := &syntaxtree.MatchExpression{Token: p.cursorToken}
+
+	// Check for the subject opening ( token
+	if !p.expectPeek(lexer.LPAREN) {
+		return nil
+	}
+
+	// Advance the parse cursor
+	p.NextToken()
+	// Parse the matched expression
+	expression.Subject = p.parseExpression(LOWEST)
+
+	// Check for the subject ending ) token and the arms opening { token
+	if !p.expectPeek(lexer.RPAREN) || !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+
+	// Iterate until the next token is the } token
+	for !p.isPeekToken(lexer.RBRACE) {
+		// Advance the parse cursor to the pattern
+		p.NextToken()
+		// Parse the arm
+		arm := p.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		expression.Arms = append(expression.Arms, arm)
+
+		// Check for the , token between arms
+		if !p.isPeekToken(lexer.RBRACE) && !p.expectPeek(lexer.COMMA) {
+			return nil
+		}
+	}
+
+	// Check for the arms ending } token
+	if !p.expectPeek(lexer.RBRACE) {
+		return nil
+	}
+
+	// Check that the match expression has arms
+	if len(expression.Arms) == 0 {
+		p.Errors = append(p.Errors, "expected at least one arm in match expression")
+		return nil
+	}
+
+	// Return the parsed match expression
+	return expression
+}
+
+// A method of Parser that parses an arm of a match expression. The body of
+// the arm is either a block statement or a single expression.
+func (p *Parser) parseMatchArm() *syntaxtree.MatchArm {
+	// Parse the pattern of the arm
+	arm := &syntaxtree.MatchArm{Pattern: p.parsePattern()}
+	if arm.Pattern == nil {
+		return nil
+	}
+
+	// Check for the guard of the arm
+	if p.isPeekToken(lexer.IF) {
+		// Advance the parse cursor to the guard expression
+		p.NextToken()
+		p.NextToken()
+		// Parse the guard expression
+		arm.Guard = p.parseExpression(LOWEST)
+	}
+
+	// Check for the => token
+	if !p.expectPeek(lexer.FATARROW) {
+		return nil
+	}
+
+	// Advance the parse cursor to the body
+	p.NextToken()
+
+	// Check if the body is a block statement
+	if p.isCursorToken(lexer.LBRACE) {
+		arm.Body = p.parseBlockStatement()
+		return arm
+	}
+
+	// Wrap the expression of the body into a block statement
+	token := p.cursorToken
+	body := &syntaxtree.ExpressionStatement{Token: token, Expression: p.parseExpression(LOWEST)}
+	arm.Body = &syntaxtree.BlockStatement{Token: token, Statements: []syntaxtree.Statement{body}}
+
+	// Return the parsed arm
+	return arm
+}
+
+// A method of Parser that parses the tokens starting at the parse cursor into a pattern
+func (p *Parser) parsePattern() syntaxtree.Pattern {
+	// Check the type of the token in the parse cursor
+	switch p.cursorToken.Type {
+
+	// Wildcard or Binding Pattern
+	case lexer.IDENT:
+		// Check for the wildcard
+		if p.cursorToken.Literal == "_" {
+			return &syntaxtree.WildcardPattern{Token: p.cursorToken}
+		}
+
+		return &syntaxtree.BindingPattern{Name: &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}}
+
+	// Literal Patterns
+	case lexer.INT, lexer.STRING, lexer.TRUE, lexer.FALSE, lexer.NULL, lexer.MINUS:
+		return p.parseLiteralPattern()
+
+	// List Pattern
+	case lexer.LBRACK:
+		return p.parseListPattern(p.parsePattern)
+
+	// Map Pattern
+	case lexer.LBRACE:
+		return p.parseMapPattern()
+
+	default:
+		// Add the error to the parser's errors
+		p.Errors = append(p.Errors, fmt.Sprintf("unexpected %s in pattern", p.cursorToken.Type))
+		return nil
+	}
+}
+
+// A method of Parser that parses a literal pattern (an integer, string, boolean or
+// null literal or a negated integer literal) at the parse cursor
+func (p *Parser) parseLiteralPattern() syntaxtree.Pattern {
+	// Create a literal pattern node with the token
+	pattern := &syntaxtree.LiteralPattern{Token: p.cursorToken}
+
+	// Check the type of the literal
+	switch p.cursorToken.Type {
+	case lexer.INT:
+		pattern.Value = p.parseIntegerLiteral()
+	case lexer.STRING:
+		pattern.Value = p.parseStringLiteral()
+	case lexer.TRUE, lexer.FALSE:
+		pattern.Value = p.parseBooleanLiteral()
+	case lexer.NULL:
+		pattern.Value = p.parseNullLiteral()
+
+	// Negated Integer Literal
+	case lexer.MINUS:
+		// Check for the integer literal
+		if !p.expectPeek(lexer.INT) {
+			return nil
+		}
+		pattern.Value = &syntaxtree.PrefixExpression{Token: pattern.Token, Operator: "-", Right: p.parseIntegerLiteral()}
+
+	default:
+		// Add the error to the parser's errors
+		p.Errors = append(p.Errors, fmt.Sprintf("unexpected %s in literal pattern", p.cursorToken.Type))
+	}
+
+	// Check if the literal could not be parsed
+	if pattern.Value == nil {
+		return nil
+	}
+
+	// Return the parsed literal pattern
+	return pattern
+}
+
+// A method of Parser that parses a list pattern at the parse
+// cursor given the parse function of its element patterns
+func (p *Parser) parseListPattern(parseElement func() syntaxtree.Pattern) syntaxtree.Pattern {
+	// Create a list pattern node with the token
+	pattern := &syntaxtree.ListPattern{Token: p.cursorToken}
+
+	// Iterate until the next token is the ] token
+	for !p.isPeekToken(lexer.RBRACK) {
+		// Advance the parse cursor
+		p.NextToken()
+
+		// Check for the rest element
+		if p.isCursorToken(lexer.ELLIPSIS) {
+			pattern.HasRest = true
+
+			// Check for the identifier of the rest element
+			if p.isPeekToken(lexer.IDENT) {
+				p.NextToken()
+				if p.cursorToken.Literal != "_" {
+					pattern.Rest = &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}
+				}
+			}
+
+			// The rest element must be the last element
+			if !p.isPeekToken(lexer.RBRACK) {
+				p.Errors = append(p.Errors, "rest element must be the last element of a list pattern")
+				return nil
+			}
+			break
+		}
+
+		// Parse the element pattern
+		element := parseElement()
+		if element == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, element)
+
+		// Check for the , token between elements
+		if !p.isPeekToken(lexer.RBRACK) && !p.expectPeek(lexer.COMMA) {
+			return nil
+		}
+	}
+
+	// Check for the ] token
+	if !p.expectPeek(lexer.RBRACK) {
+		return nil
+	}
+
+	// Return the parsed list pattern
+	return pattern
+}
+
+// A method of Parser that parses a map pattern at the parse cursor
+func (p *Parser) parseMapPattern() syntaxtree.Pattern {
+	// Create a map pattern node with the token
+	pattern := &syntaxtree.MapPattern{Token: p.cursorToken}
+
+	// Iterate until the next token is the } token
+	for !p.isPeekToken(lexer.RBRACE) {
+		// Advance the parse cursor
+		p.NextToken()
+
+		// Parse the literal key
+		key, ok := p.parseLiteralPattern().(*syntaxtree.LiteralPattern)
+		if !ok {
+			return nil
+		}
+
+		// Check for the : token
+		if !p.expectPeek(lexer.COLON) {
+			return nil
+		}
+
+		// Advance the parse cursor
+		p.NextToken()
+		// Parse the value pattern
+		value := p.parsePattern()
+		if value == nil {
+			return nil
+		}
+
+		pattern.Keys = append(pattern.Keys, key.Value)
+		pattern.Values = append(pattern.Values, value)
+
+		// Check for the , token between pairs
+		if !p.isPeekToken(lexer.RBRACE) && !p.expectPeek(lexer.COMMA) {
+			return nil
+		}
+	}
+
+	// Check for the } token
+	if !p.expectPeek(lexer.RBRACE) {
+		return nil
+	}
+
+	// Return the parsed map pattern
+	return pattern
+}
+
+// A method of Parser that parses the pattern of a destructuring let statement at the parse
+// cursor. Destructuring patterns are binding, wildcard, list and map patterns whose elements
+// and values may have default values. The keys of map patterns are names or string literals.
+func (p *Parser) parseDestructuringPattern() syntaxtree.Pattern {
+	// Check the type of the token in the parse cursor
+	switch p.cursorToken.Type {
+
+	// Wildcard or Binding Pattern
+	case lexer.IDENT:
+		return p.parsePattern()
+
+	// List Pattern
+	case lexer.LBRACK:
+		return p.parseListPattern(p.parseDestructuringElement)
+
+	// Map Pattern
+	case lexer.LBRACE:
+		return p.parseMapDestructuring()
+
+	default:
+		// Add the error to the parser's errors
+		p.Errors = append(p.Errors, fmt.Sprintf("unexpected %s in destructuring pattern", p.cursorToken.Type))
+		return nil
+	}
+}
+
+// A method of Parser that parses an element of a destructuring pattern
+// at the parse cursor, which is a pattern with an optional default value
+func (p *Parser) parseDestructuringElement() syntaxtree.Pattern {
+	// Parse the pattern of the element
+	pattern := p.parseDestructuringPattern()
+	if pattern == nil {
+		return nil
+	}
+
+	// Parse the default value of the element
+	return p.parseDefaultValue(pattern)
+}
+
+// A method of Parser that parses the optional default value
+// that follows a destructuring pattern and wraps the pattern with it
+func (p *Parser) parseDefaultValue(pattern syntaxtree.Pattern) syntaxtree.Pattern {
+	// Check for the = token
+	if !p.isPeekToken(lexer.ASSIGN) {
+		return pattern
+	}
+
+	// Advance the parse cursor to the default value
+	p.NextToken()
+	defaulted := &syntaxtree.DefaultPattern{Token: p.cursorToken, Pattern: pattern}
+	p.NextToken()
+
+	// Parse the default value
+	if defaulted.Default = p.parseExpression(LOWEST); defaulted.Default == nil {
+		return nil
+	}
+
+	// Return the pattern with the default value
+	return defaulted
+}
+
+// A method of Parser that parses a map pattern of a destructuring let statement at the parse cursor.
+// A name key without a value pattern ({name}) binds the value of the key to the same name.
+func (p *Parser) parseMapDestructuring() syntaxtree.Pattern {
+	// Create a map pattern node with the token
+	pattern := &syntaxtree.MapPattern{Token: p.cursorToken}
+
+	// Iterate until the next token is the } token
+	for !p.isPeekToken(lexer.RBRACE) {
+		// Advance the parse cursor
+		p.NextToken()
+
+		// Check the type of the key
+		var value syntaxtree.Pattern
+		key := &syntaxtree.StringLiteral{Token: p.cursorToken, Value: p.cursorToken.Literal}
+		switch {
+
+		// Name key without a value pattern
+		case p.isCursorToken(lexer.IDENT) && !p.isPeekToken(lexer.COLON):
+			value = p.parseDefaultValue(&syntaxtree.BindingPattern{Name: &syntaxtree.Identifier{Token: p.cursorToken, Value: p.cursorToken.Literal}})
+
+		// Name or string key with a value pattern
+		case p.isCursorToken(lexer.IDENT) || p.isCursorToken(lexer.STRING):
+			// Check for the : token
+			if !p.expectPeek(lexer.COLON) {
+				return nil
+			}
+
+			// Advance the parse cursor
+			p.NextToken()
+			// Parse the value pattern
+			value = p.parseDestructuringElement()
+
+		default:
+			// Add the error to the parser's errors
+			p.Errors = append(p.Errors, fmt.Sprintf("unexpected %s as key of destructuring pattern", p.cursorToken.Type))
+			return nil
+		}
+
+		// Check if the value pattern could not be parsed
+		if value == nil {
+			return nil
+		}
+
+		pattern.Keys = append(pattern.Keys, key)
+		pattern.Values = append(pattern.Values, value)
+
+		// Check for the , token between pairs
+		if !p.isPeekToken(lexer.RBRACE) && !p.expectPeek(lexer.COMMA) {
+			return nil
+		}
+	}
+
+	// Check for the } token
+	if !p.expectPeek(lexer.RBRACE) {
+		return nil
+	}
+
+	// Return the parsed map pattern
+	return pattern
+}
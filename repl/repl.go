@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/manishmeganathan/tunalang/evaluator"
 	"github.com/manishmeganathan/tunalang/lexer"
@@ -19,6 +21,8 @@ func StartREPL(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
 	// Create a new execution environment
 	env := object.NewEnvironment()
+	// Create an interpreter that writes to the REPL output
+	interpreter := evaluator.NewInterpreter(evaluator.WithStdout(out), evaluator.WithStderr(out))
 
 	for {
 		// Print the REPL line prompt
@@ -45,7 +49,14 @@ func StartREPL(in io.Reader, out io.Writer) {
 		}
 
 		// Evaluate the Program
-		evaluated := evaluator.Evaluate(program, env)
+		evaluated := interpreter.Evaluate(program, env)
+		// Print a traceback for errors raised within function calls
+		if errObj, ok := evaluated.(*object.Error); ok && len(errObj.Stack) > 0 {
+			io.WriteString(out, errObj.Traceback())
+			io.WriteString(out, "\n")
+			continue
+		}
+
 		// Print the evaluated values if they exist
 		if evaluated != nil {
 			io.WriteString(out, evaluated.Inspect())
@@ -54,6 +65,44 @@ func StartREPL(in io.Reader, out io.Writer) {
 	}
 }
 
+// A function that runs a Tuna script file and prints a traceback for its runtime
+// errors. It returns false if the script could not be read, parsed or evaluated.
+func RunFile(path string, out, errout io.Writer) bool {
+	// Read the script file
+	source, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(errout, "could not read script: %s\n", err)
+		return false
+	}
+
+	// Parse the script into a Program
+	par := parser.NewParser(lexer.NewLexer(string(source)))
+	program := par.ParseProgram()
+	// Check for parser errors
+	if len(par.Errors) != 0 {
+		printParserErrors(errout, par.Errors)
+		return false
+	}
+
+	// Evaluate the Program with an interpreter that writes to the output
+	// and imports modules from the directory of the script and the working directory
+	interpreter := evaluator.NewInterpreter(
+		evaluator.WithStdout(out),
+		evaluator.WithStderr(errout),
+		evaluator.WithModulePaths(filepath.Dir(path), "."),
+	)
+	evaluated := interpreter.Evaluate(program, object.NewEnvironment())
+
+	// Print a traceback if the evaluation resulted in an error
+	if errObj, ok := evaluated.(*object.Error); ok {
+		io.WriteString(errout, errObj.Traceback())
+		io.WriteString(errout, "\n")
+		return false
+	}
+
+	return true
+}
+
 func printParserErrors(out io.Writer, errors []string) {
 	// Print some error header
 	io.WriteString(out, "Whoops! We had some trouble parsing!\n")
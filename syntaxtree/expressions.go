@@ -174,6 +174,9 @@ type IndexExpression struct {
 
 	// Represents the index of the expression
 	Index Expression
+
+	// Represents whether the index is optional (?.[), which short-circuits the rest of the chain for a null expression
+	Optional bool
 }
 
 // A method of IndexExpression to satisfy the Expression interface
@@ -192,6 +195,9 @@ func (ie *IndexExpression) String() string {
 	// Add the left expression
 	out.WriteString(ie.Left.String())
 	// Add the index
+	if ie.Optional {
+		out.WriteString("?.")
+	}
 	out.WriteString("[")
 	out.WriteString(ie.Index.String())
 	out.WriteString("])")
@@ -199,3 +205,157 @@ func (ie *IndexExpression) String() string {
 	// Return the string of the buffer
 	return out.String()
 }
+
+// A structure that represents a member access expression node on the syntax tree
+type MemberExpression struct {
+	// Represents the . token
+	Token lexer.Token
+
+	// Represents the expression whose member is accessed
+	Object Expression
+
+	// Represents the name of the accessed member
+	Property *Identifier
+
+	// Represents whether the access is optional (?.), which short-circuits the rest of the chain for a null object
+	Optional bool
+}
+
+// A method of MemberExpression to satisfy the Expression interface
+func (me *MemberExpression) expressionNode() {}
+
+// A method of MemberExpression that returns its token literal value
+func (me *MemberExpression) TokenLiteral() string { return me.Token.Literal }
+
+// A method of MemberExpression that returns its string representation
+func (me *MemberExpression) String() string {
+	// Check if the access is optional
+	if me.Optional {
+		return "(" + me.Object.String() + "?." + me.Property.String() + ")"
+	}
+
+	return "(" + me.Object.String() + "." + me.Property.String() + ")"
+}
+
+// A structure that represents a slice expression node on the syntax tree
+type SliceExpression struct {
+	// Represents the [ token
+	Token lexer.Token
+
+	// Represents the sliceable expression
+	Left Expression
+
+	// Represents the start, end and step of the slice (nil if omitted)
+	Start Expression
+	End   Expression
+	Step  Expression
+
+	// Represents whether the slice is optional (?.[), which short-circuits the rest of the chain for a null expression
+	Optional bool
+}
+
+// A method of SliceExpression to satisfy the Expression interface
+func (se *SliceExpression) expressionNode() {}
+
+// A method of SliceExpression that returns its token literal value
+func (se *SliceExpression) TokenLiteral() string { return se.Token.Literal }
+
+// A method of SliceExpression that returns its string representation
+func (se *SliceExpression) String() string {
+	// Declare a bytes buffer
+	var out bytes.Buffer
+
+	// Start expression with parenthesis
+	out.WriteString("(")
+	// Add the left expression
+	out.WriteString(se.Left.String())
+	// Add the bounds of the slice
+	if se.Optional {
+		out.WriteString("?.")
+	}
+	out.WriteString("[")
+	if se.Start != nil {
+		out.WriteString(se.Start.String())
+	}
+	out.WriteString(":")
+	if se.End != nil {
+		out.WriteString(se.End.String())
+	}
+	// Add the step if it exists
+	if se.Step != nil {
+		out.WriteString(":")
+		out.WriteString(se.Step.String())
+	}
+	out.WriteString("])")
+
+	// Return the string of the buffer
+	return out.String()
+}
+
+// A structure that represents a parenthesized optional chain on the syntax tree, which ends
+// the chain such that the accesses and calls that follow it are applied to its value
+type GroupedExpression struct {
+	// Represents the ( token
+	Token lexer.Token
+
+	// Represents the expression in the parentheses
+	Expression Expression
+}
+
+// A method of GroupedExpression to satisfy the Expression interface
+func (ge *GroupedExpression) expressionNode() {}
+
+// A method of GroupedExpression that returns its token literal value
+func (ge *GroupedExpression) TokenLiteral() string { return ge.Token.Literal }
+
+// A method of GroupedExpression that returns its string representation
+func (ge *GroupedExpression) String() string { return "(" + ge.Expression.String() + ")" }
+
+// A structure that represents a try expression node on the syntax tree
+type TryExpression struct {
+	// Represents the TRY token
+	Token lexer.Token
+
+	// Represents the block of statements that is tried
+	Block *BlockStatement
+
+	// Represents the identifier bound to the caught value (nil if there is no catch block)
+	Parameter *Identifier
+
+	// Represents the block of statements evaluated when an error is caught
+	Catch *BlockStatement
+
+	// Represents the block of statements that is always evaluated
+	Finally *BlockStatement
+}
+
+// A method of TryExpression to satisfy the Expression interface
+func (te *TryExpression) expressionNode() {}
+
+// A method of TryExpression that returns its token literal value
+func (te *TryExpression) TokenLiteral() string { return te.Token.Literal }
+
+// A method of TryExpression that returns its string representation
+func (te *TryExpression) String() string {
+	// Declare a bytes buffer
+	var out bytes.Buffer
+
+	// Start expression with try and the tried block
+	out.WriteString("try ")
+	out.WriteString(te.Block.String())
+	// Add the catch block if it exists
+	if te.Catch != nil {
+		out.WriteString(" catch (")
+		out.WriteString(te.Parameter.String())
+		out.WriteString(") ")
+		out.WriteString(te.Catch.String())
+	}
+	// Add the finally block if it exists
+	if te.Finally != nil {
+		out.WriteString(" finally ")
+		out.WriteString(te.Finally.String())
+	}
+
+	// Return the string of the buffer
+	return out.String()
+}
@@ -61,6 +61,21 @@ func (b *BooleanLiteral) TokenLiteral() string { return b.Token.Literal }
 // A method of BooleanLiteral that returns its string representation
 func (b *BooleanLiteral) String() string { return b.Token.Literal }
 
+// A structure that represents a Null literal
+type NullLiteral struct {
+	// Represents the lexological token 'NULL'
+	Token lexer.Token
+}
+
+// A method of NullLiteral to satisfy the Expression interface
+func (n *NullLiteral) expressionNode() {}
+
+// A method of NullLiteral that returns its token literal value
+func (n *NullLiteral) TokenLiteral() string { return n.Token.Literal }
+
+// A method of NullLiteral that returns its string representation
+func (n *NullLiteral) String() string { return n.Token.Literal }
+
 // A structure that represents an String literal
 type StringLiteral struct {
 	// Represents the lexological token 'STRING'
@@ -79,6 +94,44 @@ func (il *StringLiteral) TokenLiteral() string { return il.Token.Literal }
 // A method of StringLiteral that returns its string representation
 func (il *StringLiteral) String() string { return il.Token.Literal }
 
+// A structure that represents an interpolated String literal. The texts of the
+// string surround the embedded expressions (there is one more text than expressions).
+type InterpolatedString struct {
+	// Represents the lexological token 'STRING_HEAD'
+	Token lexer.Token
+
+	// Represents the texts before, between and after the embedded expressions
+	Texts []string
+
+	// Represents the embedded expressions
+	Expressions []Expression
+}
+
+// A method of InterpolatedString to satisfy the Expression interface
+func (is *InterpolatedString) expressionNode() {}
+
+// A method of InterpolatedString that returns its token literal value
+func (is *InterpolatedString) TokenLiteral() string { return is.Token.Literal }
+
+// A method of InterpolatedString that returns its string representation
+func (is *InterpolatedString) String() string {
+	// Declare a bytes buffer
+	var out bytes.Buffer
+
+	// Add the texts and the embedded expressions
+	out.WriteString(`"`)
+	for idx, text := range is.Texts {
+		out.WriteString(text)
+		if idx < len(is.Expressions) {
+			out.WriteString("${" + is.Expressions[idx].String() + "}")
+		}
+	}
+	out.WriteString(`"`)
+
+	// Return the string of the buffer
+	return out.String()
+}
+
 // A structure that represents a Function literal
 type FunctionLiteral struct {
 	// Represents the lexological token 'FN'
@@ -123,6 +176,35 @@ func (fl *FunctionLiteral) String() string {
 	return out.String()
 }
 
+// A structure that represents a Macro literal
+type MacroLiteral struct {
+	// Represents the lexological token 'MACRO'
+	Token lexer.Token
+
+	// Represent the list of macro parameters
+	Parameters []*Identifier
+
+	// Represents the block of statements in the macro
+	Body *BlockStatement
+}
+
+// A method of MacroLiteral to satisfy the Expression interface
+func (ml *MacroLiteral) expressionNode() {}
+
+// A method of MacroLiteral that returns its token literal value
+func (ml *MacroLiteral) TokenLiteral() string { return ml.Token.Literal }
+
+// A method of MacroLiteral that returns its string representation
+func (ml *MacroLiteral) String() string {
+	// Collect the parameters
+	params := []string{}
+	for _, p := range ml.Parameters {
+		params = append(params, p.String())
+	}
+
+	return ml.TokenLiteral() + "(" + strings.Join(params, ", ") + ") " + ml.Body.String()
+}
+
 // A structure that represents a List literal
 type ListLiteral struct {
 	// Represents the lexological token '['
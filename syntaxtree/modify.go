@@ -0,0 +1,510 @@
+package syntaxtree
+
+// A type alias that represents a function that is called with a node
+// of the syntax tree and returns the node that replaces it
+type ModifierFunc func(Node) Node
+
+// A function that modifies the syntax tree rooted at a node by replacing each node with the result
+// of the modifier, which is called on a node after its children have been modified. The tree is not
+// mutated, a node is copied if any of its children are replaced. A replacement that is not of the
+// kind required at its position (such as an expression in place of a parameter) is ignored. The
+// names of accessed members and of the fields of struct and enum declarations are not visited.
+func Modify(node Node, modifier ModifierFunc) Node {
+	// Check the type of the node
+	switch node := node.(type) {
+
+	// Program Node
+	case *Program:
+		statements, changed := modifyStatements(node.Statements, modifier)
+		if changed {
+			node = &Program{Statements: statements}
+		}
+
+		return modifier(node)
+
+	// Statement Nodes
+	case *ExpressionStatement:
+		expression := modifyExpression(node.Expression, modifier)
+		if expression != node.Expression {
+			copied := *node
+			copied.Expression = expression
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *BlockStatement:
+		statements, changed := modifyStatements(node.Statements, modifier)
+		if changed {
+			copied := *node
+			copied.Statements = statements
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *LetStatement:
+		name := modifyIdentifier(node.Name, modifier)
+		pattern := modifyPattern(node.Pattern, modifier)
+		value := modifyExpression(node.Value, modifier)
+		if name != node.Name || pattern != node.Pattern || value != node.Value {
+			copied := *node
+			copied.Name, copied.Pattern, copied.Value = name, pattern, value
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *ReturnStatement:
+		value := modifyExpression(node.ReturnValue, modifier)
+		if value != node.ReturnValue {
+			copied := *node
+			copied.ReturnValue = value
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *ThrowStatement:
+		value := modifyExpression(node.Value, modifier)
+		if value != node.Value {
+			copied := *node
+			copied.Value = value
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *ImportStatement:
+		name := modifyIdentifier(node.Name, modifier)
+		if name != node.Name {
+			copied := *node
+			copied.Name = name
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *ExportStatement:
+		declaration, ok := Modify(node.Declaration, modifier).(Declaration)
+		if ok && declaration != node.Declaration {
+			copied := *node
+			copied.Declaration = declaration
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *FunctionStatement:
+		name := modifyIdentifier(node.Name, modifier)
+		function, ok := Modify(node.Function, modifier).(*FunctionLiteral)
+		if !ok {
+			function = node.Function
+		}
+
+		if name != node.Name || function != node.Function {
+			copied := *node
+			copied.Name, copied.Function = name, function
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *StructStatement:
+		name := modifyIdentifier(node.Name, modifier)
+		methods := make([]*FunctionStatement, len(node.Methods))
+		changed := name != node.Name
+		for idx, method := range node.Methods {
+			methods[idx] = method
+			if modified, ok := Modify(method, modifier).(*FunctionStatement); ok {
+				methods[idx] = modified
+			}
+			changed = changed || methods[idx] != method
+		}
+
+		if changed {
+			copied := *node
+			copied.Name, copied.Methods = name, methods
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *EnumStatement:
+		name := modifyIdentifier(node.Name, modifier)
+		if name != node.Name {
+			copied := *node
+			copied.Name = name
+			node = &copied
+		}
+
+		return modifier(node)
+
+	// Expression Nodes
+	case *PrefixExpression:
+		right := modifyExpression(node.Right, modifier)
+		if right != node.Right {
+			copied := *node
+			copied.Right = right
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *InfixExpression:
+		left := modifyExpression(node.Left, modifier)
+		right := modifyExpression(node.Right, modifier)
+		if left != node.Left || right != node.Right {
+			copied := *node
+			copied.Left, copied.Right = left, right
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *IfExpression:
+		condition := modifyExpression(node.Condition, modifier)
+		consequence := modifyBlock(node.Consequence, modifier)
+		alternative := modifyBlock(node.Alternative, modifier)
+		if condition != node.Condition || consequence != node.Consequence || alternative != node.Alternative {
+			copied := *node
+			copied.Condition, copied.Consequence, copied.Alternative = condition, consequence, alternative
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *CallExpression:
+		function := modifyExpression(node.Function, modifier)
+		arguments, changed := modifyExpressions(node.Arguments, modifier)
+		if changed || function != node.Function {
+			copied := *node
+			copied.Function, copied.Arguments = function, arguments
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *IndexExpression:
+		left := modifyExpression(node.Left, modifier)
+		index := modifyExpression(node.Index, modifier)
+		if left != node.Left || index != node.Index {
+			copied := *node
+			copied.Left, copied.Index = left, index
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *MemberExpression:
+		object := modifyExpression(node.Object, modifier)
+		if object != node.Object {
+			copied := *node
+			copied.Object = object
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *SliceExpression:
+		left := modifyExpression(node.Left, modifier)
+		start := modifyExpression(node.Start, modifier)
+		end := modifyExpression(node.End, modifier)
+		step := modifyExpression(node.Step, modifier)
+		if left != node.Left || start != node.Start || end != node.End || step != node.Step {
+			copied := *node
+			copied.Left, copied.Start, copied.End, copied.Step = left, start, end, step
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *GroupedExpression:
+		expression := modifyExpression(node.Expression, modifier)
+		if expression != node.Expression {
+			copied := *node
+			copied.Expression = expression
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *TryExpression:
+		block := modifyBlock(node.Block, modifier)
+		parameter := modifyIdentifier(node.Parameter, modifier)
+		catch := modifyBlock(node.Catch, modifier)
+		finally := modifyBlock(node.Finally, modifier)
+		if block != node.Block || parameter != node.Parameter || catch != node.Catch || finally != node.Finally {
+			copied := *node
+			copied.Block, copied.Parameter, copied.Catch, copied.Finally = block, parameter, catch, finally
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *MatchExpression:
+		subject := modifyExpression(node.Subject, modifier)
+		arms := make([]*MatchArm, len(node.Arms))
+		changed := subject != node.Subject
+		for idx, arm := range node.Arms {
+			// Modify the pattern, the guard and the body of the arm
+			pattern := modifyPattern(arm.Pattern, modifier)
+			guard := modifyExpression(arm.Guard, modifier)
+			body := modifyBlock(arm.Body, modifier)
+
+			arms[idx] = arm
+			if pattern != arm.Pattern || guard != arm.Guard || body != arm.Body {
+				arms[idx] = &MatchArm{Pattern: pattern, Guard: guard, Body: body}
+				changed = true
+			}
+		}
+
+		if changed {
+			copied := *node
+			copied.Subject, copied.Arms = subject, arms
+			node = &copied
+		}
+
+		return modifier(node)
+
+	// Literal Nodes
+	case *InterpolatedString:
+		expressions, changed := modifyExpressions(node.Expressions, modifier)
+		if changed {
+			copied := *node
+			copied.Expressions = expressions
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *FunctionLiteral:
+		parameters, changed := modifyIdentifiers(node.Parameters, modifier)
+		body := modifyBlock(node.Body, modifier)
+		if changed || body != node.Body {
+			copied := *node
+			copied.Parameters, copied.Body = parameters, body
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *MacroLiteral:
+		parameters, changed := modifyIdentifiers(node.Parameters, modifier)
+		body := modifyBlock(node.Body, modifier)
+		if changed || body != node.Body {
+			copied := *node
+			copied.Parameters, copied.Body = parameters, body
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *ListLiteral:
+		elements, changed := modifyExpressions(node.Elements, modifier)
+		if changed {
+			copied := *node
+			copied.Elements = elements
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *MapLiteral:
+		pairs := make(map[Expression]Expression, len(node.Pairs))
+		changed := false
+		for key, value := range node.Pairs {
+			modifiedKey := modifyExpression(key, modifier)
+			modifiedValue := modifyExpression(value, modifier)
+			pairs[modifiedKey] = modifiedValue
+			changed = changed || modifiedKey != key || modifiedValue != value
+		}
+
+		if changed {
+			copied := *node
+			copied.Pairs = pairs
+			node = &copied
+		}
+
+		return modifier(node)
+
+	// Pattern Nodes
+	case *BindingPattern:
+		name := modifyIdentifier(node.Name, modifier)
+		if name != node.Name {
+			node = &BindingPattern{Name: name}
+		}
+
+		return modifier(node)
+
+	case *LiteralPattern:
+		value := modifyExpression(node.Value, modifier)
+		if value != node.Value {
+			copied := *node
+			copied.Value = value
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *ListPattern:
+		elements, changed := modifyPatterns(node.Elements, modifier)
+		rest := modifyIdentifier(node.Rest, modifier)
+		if changed || rest != node.Rest {
+			copied := *node
+			copied.Elements, copied.Rest = elements, rest
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *MapPattern:
+		keys, keysChanged := modifyExpressions(node.Keys, modifier)
+		values, valuesChanged := modifyPatterns(node.Values, modifier)
+		if keysChanged || valuesChanged {
+			copied := *node
+			copied.Keys, copied.Values = keys, values
+			node = &copied
+		}
+
+		return modifier(node)
+
+	case *DefaultPattern:
+		pattern := modifyPattern(node.Pattern, modifier)
+		value := modifyExpression(node.Default, modifier)
+		if pattern != node.Pattern || value != node.Default {
+			copied := *node
+			copied.Pattern, copied.Default = pattern, value
+			node = &copied
+		}
+
+		return modifier(node)
+
+	// Leaf Nodes (identifiers, scalar literals and wildcard patterns)
+	default:
+		return modifier(node)
+	}
+}
+
+// A function that modifies an expression and returns it (or the
+// expression itself if it is nil or was replaced by a non-expression)
+func modifyExpression(expression Expression, modifier ModifierFunc) Expression {
+	// Check if the expression is omitted
+	if expression == nil {
+		return nil
+	}
+
+	// Check that the replacement is an expression
+	if modified, ok := Modify(expression, modifier).(Expression); ok {
+		return modified
+	}
+
+	return expression
+}
+
+// A function that modifies an identifier and returns it (or the identifier
+// itself if it is nil or was replaced by a node that is not an identifier)
+func modifyIdentifier(ident *Identifier, modifier ModifierFunc) *Identifier {
+	// Check if the identifier is omitted
+	if ident == nil {
+		return nil
+	}
+
+	// Check that the replacement is an identifier
+	if modified, ok := Modify(ident, modifier).(*Identifier); ok {
+		return modified
+	}
+
+	return ident
+}
+
+// A function that modifies a pattern and returns it (or the pattern
+// itself if it is nil or was replaced by a node that is not a pattern)
+func modifyPattern(pattern Pattern, modifier ModifierFunc) Pattern {
+	// Check if the pattern is omitted
+	if pattern == nil {
+		return nil
+	}
+
+	// Check that the replacement is a pattern
+	if modified, ok := Modify(pattern, modifier).(Pattern); ok {
+		return modified
+	}
+
+	return pattern
+}
+
+// A function that modifies a block statement and returns it (or the block
+// itself if it is nil or was replaced by a node that is not a block statement)
+func modifyBlock(block *BlockStatement, modifier ModifierFunc) *BlockStatement {
+	// Check if the block is omitted
+	if block == nil {
+		return nil
+	}
+
+	// Check that the replacement is a block statement
+	if modified, ok := Modify(block, modifier).(*BlockStatement); ok {
+		return modified
+	}
+
+	return block
+}
+
+// A function that modifies a slice of statements and returns the modified
+// slice and whether any statement was replaced (statements cannot be omitted)
+func modifyStatements(statements []Statement, modifier ModifierFunc) ([]Statement, bool) {
+	modified := make([]Statement, len(statements))
+	changed := false
+
+	for idx, statement := range statements {
+		modified[idx] = statement
+		if replacement, ok := Modify(statement, modifier).(Statement); ok {
+			modified[idx] = replacement
+		}
+		changed = changed || modified[idx] != statement
+	}
+
+	return modified, changed
+}
+
+// A function that modifies a slice of expressions and returns
+// the modified slice and whether any expression was replaced
+func modifyExpressions(expressions []Expression, modifier ModifierFunc) ([]Expression, bool) {
+	modified := make([]Expression, len(expressions))
+	changed := false
+
+	for idx, expression := range expressions {
+		modified[idx] = modifyExpression(expression, modifier)
+		changed = changed || modified[idx] != expression
+	}
+
+	return modified, changed
+}
+
+// A function that modifies a slice of identifiers and returns
+// the modified slice and whether any identifier was replaced
+func modifyIdentifiers(idents []*Identifier, modifier ModifierFunc) ([]*Identifier, bool) {
+	modified := make([]*Identifier, len(idents))
+	changed := false
+
+	for idx, ident := range idents {
+		modified[idx] = modifyIdentifier(ident, modifier)
+		changed = changed || modified[idx] != ident
+	}
+
+	return modified, changed
+}
+
+// A function that modifies a slice of patterns and returns
+// the modified slice and whether any pattern was replaced
+func modifyPatterns(patterns []Pattern, modifier ModifierFunc) ([]Pattern, bool) {
+	modified := make([]Pattern, len(patterns))
+	changed := false
+
+	for idx, pattern := range patterns {
+		modified[idx] = modifyPattern(pattern, modifier)
+		changed = changed || modified[idx] != pattern
+	}
+
+	return modified, changed
+}
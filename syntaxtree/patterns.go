@@ -0,0 +1,260 @@
+package syntaxtree
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/manishmeganathan/tunalang/lexer"
+)
+
+// A structure that represents a wildcard pattern (_) that matches any value
+type WildcardPattern struct {
+	// Represents the '_' token
+	Token lexer.Token
+}
+
+// A method of WildcardPattern to satisfy the Pattern interface
+func (wp *WildcardPattern) patternNode() {}
+
+// A method of WildcardPattern that returns its token literal value
+func (wp *WildcardPattern) TokenLiteral() string { return wp.Token.Literal }
+
+// A method of WildcardPattern that returns its string representation
+func (wp *WildcardPattern) String() string { return "_" }
+
+// A structure that represents a binding pattern that matches
+// any value and binds it to the name of the pattern
+type BindingPattern struct {
+	// Represents the identifier that the value is bound to
+	Name *Identifier
+}
+
+// A method of BindingPattern to satisfy the Pattern interface
+func (bp *BindingPattern) patternNode() {}
+
+// A method of BindingPattern that returns its token literal value
+func (bp *BindingPattern) TokenLiteral() string { return bp.Name.TokenLiteral() }
+
+// A method of BindingPattern that returns its string representation
+func (bp *BindingPattern) String() string { return bp.Name.String() }
+
+// A structure that represents a literal pattern that matches values equal to the literal
+type LiteralPattern struct {
+	// Represents the first token of the literal
+	Token lexer.Token
+
+	// Represents the literal value (an integer, string, boolean or null literal or a negated integer)
+	Value Expression
+}
+
+// A method of LiteralPattern to satisfy the Pattern interface
+func (lp *LiteralPattern) patternNode() {}
+
+// A method of LiteralPattern that returns its token literal value
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token.Literal }
+
+// A method of LiteralPattern that returns its string representation
+func (lp *LiteralPattern) String() string {
+	// Quote string literals
+	if str, ok := lp.Value.(*StringLiteral); ok {
+		return `"` + str.Value + `"`
+	}
+
+	return lp.Value.String()
+}
+
+// A structure that represents a list pattern that matches Lists element-wise. A list pattern
+// with a rest element (...rest) matches Lists with at least as many elements as the pattern.
+type ListPattern struct {
+	// Represents the '[' token
+	Token lexer.Token
+
+	// Represents the patterns of the elements
+	Elements []Pattern
+
+	// Represents whether the pattern has a rest element
+	HasRest bool
+
+	// Represents the identifier bound to the remaining elements (nil if they are not bound)
+	Rest *Identifier
+}
+
+// A method of ListPattern to satisfy the Pattern interface
+func (lp *ListPattern) patternNode() {}
+
+// A method of ListPattern that returns its token literal value
+func (lp *ListPattern) TokenLiteral() string { return lp.Token.Literal }
+
+// A method of ListPattern that returns its string representation
+func (lp *ListPattern) String() string {
+	// Collect the element patterns
+	elements := []string{}
+	for _, element := range lp.Elements {
+		elements = append(elements, element.String())
+	}
+
+	// Add the rest element
+	if lp.HasRest {
+		rest := "..."
+		if lp.Rest != nil {
+			rest += lp.Rest.String()
+		}
+		elements = append(elements, rest)
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// A structure that represents a map pattern that matches Maps which have the keys
+// of the pattern with matching values. Other keys of the Map are ignored.
+type MapPattern struct {
+	// Represents the '{' token
+	Token lexer.Token
+
+	// Represents the literal keys of the pattern
+	Keys []Expression
+
+	// Represents the patterns of the values of the keys
+	Values []Pattern
+}
+
+// A method of MapPattern to satisfy the Pattern interface
+func (mp *MapPattern) patternNode() {}
+
+// A method of MapPattern that returns its token literal value
+func (mp *MapPattern) TokenLiteral() string { return mp.Token.Literal }
+
+// A method of MapPattern that returns its string representation
+func (mp *MapPattern) String() string {
+	// Collect the key-value patterns
+	pairs := []string{}
+	for idx, key := range mp.Keys {
+		pairs = append(pairs, (&LiteralPattern{Value: key}).String()+": "+mp.Values[idx].String())
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// A structure that represents a pattern of a destructuring let statement with a default
+// value, which is evaluated and matched when the destructured element or key is missing
+type DefaultPattern struct {
+	// Represents the '=' token
+	Token lexer.Token
+
+	// Represents the pattern that the element or key is matched against
+	Pattern Pattern
+
+	// Represents the default value of the element or key
+	Default Expression
+}
+
+// A method of DefaultPattern to satisfy the Pattern interface
+func (dp *DefaultPattern) patternNode() {}
+
+// A method of DefaultPattern that returns its token literal value
+func (dp *DefaultPattern) TokenLiteral() string { return dp.Token.Literal }
+
+// A method of DefaultPattern that returns its string representation
+func (dp *DefaultPattern) String() string { return dp.Pattern.String() + " = " + dp.Default.String() }
+
+// A function that returns the identifiers bound by a pattern in the order they appear
+func patternNames(pattern Pattern) []*Identifier {
+	// Check the type of the pattern
+	switch pattern := pattern.(type) {
+
+	// Binding Pattern
+	case *BindingPattern:
+		return []*Identifier{pattern.Name}
+
+	// Default Pattern
+	case *DefaultPattern:
+		return patternNames(pattern.Pattern)
+
+	// List Pattern
+	case *ListPattern:
+		names := []*Identifier{}
+		for _, element := range pattern.Elements {
+			names = append(names, patternNames(element)...)
+		}
+		if pattern.Rest != nil {
+			names = append(names, pattern.Rest)
+		}
+
+		return names
+
+	// Map Pattern
+	case *MapPattern:
+		names := []*Identifier{}
+		for _, value := range pattern.Values {
+			names = append(names, patternNames(value)...)
+		}
+
+		return names
+
+	default:
+		return nil
+	}
+}
+
+// A structure that represents an arm of a match expression
+type MatchArm struct {
+	// Represents the pattern of the arm
+	Pattern Pattern
+
+	// Represents the guard of the arm (nil if the arm has no guard)
+	Guard Expression
+
+	// Represents the body of the arm
+	Body *BlockStatement
+}
+
+// A method of MatchArm that returns the identifiers bound by its pattern
+func (ma *MatchArm) Names() []*Identifier { return patternNames(ma.Pattern) }
+
+// A method of MatchArm that returns its string representation
+func (ma *MatchArm) String() string {
+	// Declare a bytes buffer
+	var out bytes.Buffer
+
+	// Add the pattern and the guard
+	out.WriteString(ma.Pattern.String())
+	if ma.Guard != nil {
+		out.WriteString(" if " + ma.Guard.String())
+	}
+
+	// Add the body
+	out.WriteString(" => ")
+	out.WriteString(ma.Body.String())
+
+	// Return the string of the buffer
+	return out.String()
+}
+
+// A structure that represents a match expression node on the syntax tree
+type MatchExpression struct {
+	// Represents the 'match' token
+	Token lexer.Token
+
+	// Represents the matched expression
+	Subject Expression
+
+	// Represents the arms of the match expression
+	Arms []*MatchArm
+}
+
+// A method of MatchExpression to satisfy the Expression interface
+func (me *MatchExpression) expressionNode() {}
+
+// A method of MatchExpression that returns its token literal value
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+
+// A method of MatchExpression that returns its string representation
+func (me *MatchExpression) String() string {
+	// Collect the arms
+	arms := []string{}
+	for _, arm := range me.Arms {
+		arms = append(arms, arm.String())
+	}
+
+	return "match (" + me.Subject.String() + ") { " + strings.Join(arms, ", ") + " }"
+}
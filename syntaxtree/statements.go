@@ -2,18 +2,23 @@ package syntaxtree
 
 import (
 	"bytes"
+	"strconv"
+	"strings"
 
 	"github.com/manishmeganathan/tunalang/lexer"
 )
 
 // A structure that represents a Let statement token
 type LetStatement struct {
-	// Represents the lexological token 'LET'
+	// Represents the lexological token 'LET' (or 'CONST' for a constant binding)
 	Token lexer.Token
 
-	// Represents the identifier in the let statement
+	// Represents the identifier in the let statement (nil if the statement destructures)
 	Name *Identifier
 
+	// Represents the list or map pattern of a destructuring let statement
+	Pattern Pattern
+
 	// Represents the value in the let statement
 	Value Expression
 }
@@ -24,6 +29,19 @@ func (ls *LetStatement) statementNode() {}
 // A method of LetStatement that returns its token literal value
 func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
 
+// A method of LetStatement that returns whether it declares a constant binding
+func (ls *LetStatement) IsConst() bool { return ls.Token.Type == lexer.CONST }
+
+// A method of LetStatement that returns the identifiers bound by the statement
+func (ls *LetStatement) Names() []*Identifier {
+	// Check if the statement destructures
+	if ls.Pattern != nil {
+		return patternNames(ls.Pattern)
+	}
+
+	return []*Identifier{ls.Name}
+}
+
 // A method of LetStatment that returns its string representation
 func (ls *LetStatement) String() string {
 	// Declare a bytes buffer
@@ -31,7 +49,11 @@ func (ls *LetStatement) String() string {
 
 	// Add the token literal and identifier string into buffer
 	out.WriteString(ls.TokenLiteral() + " ")
-	out.WriteString(ls.Name.String())
+	if ls.Pattern != nil {
+		out.WriteString(ls.Pattern.String())
+	} else {
+		out.WriteString(ls.Name.String())
+	}
 	out.WriteString(" = ")
 
 	// Check if let statement has a value
@@ -80,6 +102,213 @@ func (rs *ReturnStatement) String() string {
 	return out.String()
 }
 
+// A structure that represents a Throw statement token
+type ThrowStatement struct {
+	// Represents the lexological token 'THROW'
+	Token lexer.Token
+
+	// Represents the value in the throw statement
+	Value Expression
+}
+
+// A method of ThrowStatement to satisfy the Statement interface
+func (ts *ThrowStatement) statementNode() {}
+
+// A method of ThrowStatement that returns its token literal value
+func (ts *ThrowStatement) TokenLiteral() string { return ts.Token.Literal }
+
+// A method of ThrowStatement that returns its string representation
+func (ts *ThrowStatement) String() string {
+	// Declare a bytes buffer
+	var out bytes.Buffer
+
+	// Add the token literal and the thrown value into the buffer
+	out.WriteString(ts.TokenLiteral() + " ")
+	out.WriteString(ts.Value.String())
+
+	// Add a semicolon
+	out.WriteString(";")
+	// Return the string of the buffer
+	return out.String()
+}
+
+// A structure that represents an Import statement token
+type ImportStatement struct {
+	// Represents the lexological token 'IMPORT'
+	Token lexer.Token
+
+	// Represents the path of the imported module
+	Path *StringLiteral
+
+	// Represents the identifier that the module is bound to
+	Name *Identifier
+}
+
+// A method of ImportStatement to satisfy the Statement interface
+func (is *ImportStatement) statementNode() {}
+
+// A method of ImportStatement that returns its token literal value
+func (is *ImportStatement) TokenLiteral() string { return is.Token.Literal }
+
+// A method of ImportStatement that returns its string representation
+func (is *ImportStatement) String() string {
+	// Declare a bytes buffer
+	var out bytes.Buffer
+
+	// Add the token literal, the quoted path and the name into the buffer
+	out.WriteString(is.TokenLiteral() + " ")
+	out.WriteString(strconv.Quote(is.Path.Value))
+	out.WriteString(" as ")
+	out.WriteString(is.Name.String())
+
+	// Add a semicolon
+	out.WriteString(";")
+	// Return the string of the buffer
+	return out.String()
+}
+
+// A structure that represents an Export statement token
+type ExportStatement struct {
+	// Represents the lexological token 'EXPORT'
+	Token lexer.Token
+
+	// Represents the exported declaration
+	Declaration Declaration
+}
+
+// A method of ExportStatement to satisfy the Statement interface
+func (es *ExportStatement) statementNode() {}
+
+// A method of ExportStatement that returns its token literal value
+func (es *ExportStatement) TokenLiteral() string { return es.Token.Literal }
+
+// A method of ExportStatement that returns its string representation
+func (es *ExportStatement) String() string {
+	return es.TokenLiteral() + " " + es.Declaration.String()
+}
+
+// A structure that represents a named Function declaration statement token
+type FunctionStatement struct {
+	// Represents the lexological token 'FN'
+	Token lexer.Token
+
+	// Represents the name of the function
+	Name *Identifier
+
+	// Represents the parameters and the body of the function
+	Function *FunctionLiteral
+}
+
+// A method of FunctionStatement to satisfy the Statement interface
+func (fs *FunctionStatement) statementNode() {}
+
+// A method of FunctionStatement that returns its token literal value
+func (fs *FunctionStatement) TokenLiteral() string { return fs.Token.Literal }
+
+// A method of FunctionStatement that returns the identifiers bound by the statement
+func (fs *FunctionStatement) Names() []*Identifier { return []*Identifier{fs.Name} }
+
+// A method of FunctionStatement that returns its string representation
+func (fs *FunctionStatement) String() string {
+	// Collect the parameters
+	params := []string{}
+	for _, param := range fs.Function.Parameters {
+		params = append(params, param.String())
+	}
+
+	return fs.TokenLiteral() + " " + fs.Name.String() + "(" + strings.Join(params, ", ") + ") " + fs.Function.Body.String()
+}
+
+// A structure that represents a Struct declaration statement token
+type StructStatement struct {
+	// Represents the lexological token 'STRUCT'
+	Token lexer.Token
+
+	// Represents the name of the struct
+	Name *Identifier
+
+	// Represents the fields of the struct
+	Fields []*Identifier
+
+	// Represents the methods of the struct
+	Methods []*FunctionStatement
+}
+
+// A method of StructStatement to satisfy the Statement interface
+func (ss *StructStatement) statementNode() {}
+
+// A method of StructStatement that returns its token literal value
+func (ss *StructStatement) TokenLiteral() string { return ss.Token.Literal }
+
+// A method of StructStatement that returns the identifiers bound by the statement
+func (ss *StructStatement) Names() []*Identifier { return []*Identifier{ss.Name} }
+
+// A method of StructStatement that returns its string representation
+func (ss *StructStatement) String() string {
+	// Collect the fields and methods
+	members := []string{}
+	for _, field := range ss.Fields {
+		members = append(members, field.String())
+	}
+	for _, method := range ss.Methods {
+		members = append(members, method.String())
+	}
+
+	return ss.TokenLiteral() + " " + ss.Name.String() + " { " + strings.Join(members, ", ") + " }"
+}
+
+// A structure that represents an Enum declaration statement token
+type EnumStatement struct {
+	// Represents the lexological token 'ENUM'
+	Token lexer.Token
+
+	// Represents the name of the enum
+	Name *Identifier
+
+	// Represents the variants of the enum
+	Variants []*EnumVariant
+}
+
+// A structure that represents a variant declared in an enum
+type EnumVariant struct {
+	// Represents the name of the variant
+	Name *Identifier
+
+	// Represents the payload fields of the variant (empty if it has no payload)
+	Fields []*Identifier
+}
+
+// A method of EnumStatement to satisfy the Statement interface
+func (es *EnumStatement) statementNode() {}
+
+// A method of EnumStatement that returns its token literal value
+func (es *EnumStatement) TokenLiteral() string { return es.Token.Literal }
+
+// A method of EnumStatement that returns the identifiers bound by the statement
+func (es *EnumStatement) Names() []*Identifier { return []*Identifier{es.Name} }
+
+// A method of EnumStatement that returns its string representation
+func (es *EnumStatement) String() string {
+	// Collect the variants
+	variants := []string{}
+	for _, variant := range es.Variants {
+		// Check if the variant has a payload
+		if len(variant.Fields) == 0 {
+			variants = append(variants, variant.Name.String())
+			continue
+		}
+
+		fields := []string{}
+		for _, field := range variant.Fields {
+			fields = append(fields, field.String())
+		}
+
+		variants = append(variants, variant.Name.String()+"("+strings.Join(fields, ", ")+")")
+	}
+
+	return es.TokenLiteral() + " " + es.Name.String() + " { " + strings.Join(variants, ", ") + " }"
+}
+
 // A structure that represents a statement wrapper for an expression
 type ExpressionStatement struct {
 	// Represents the first token of the expression
@@ -16,6 +16,13 @@ type Statement interface {
 	statementNode()
 }
 
+// An interface that represents a declaration statement
+// node on the Abstract Syntax Tree that binds names
+type Declaration interface {
+	Statement
+	Names() []*Identifier
+}
+
 // An interface that represents an expression
 // node on the Abstract Syntax Tree
 type Expression interface {
@@ -23,6 +30,13 @@ type Expression interface {
 	expressionNode()
 }
 
+// An interface that represents a pattern
+// node on the Abstract Syntax Tree
+type Pattern interface {
+	Node
+	patternNode()
+}
+
 // A structure that represents the collection
 // of statements in the program
 type Program struct {
@@ -1,6 +1,7 @@
 package syntaxtree
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/manishmeganathan/tunalang/lexer"
@@ -27,3 +28,133 @@ func TestString(t *testing.T) {
 		t.Errorf("program.String() wrong. got=%q", program.String())
 	}
 }
+
+func TestModify(t *testing.T) {
+	one := func() Expression { return &IntegerLiteral{Token: lexer.Token{Type: lexer.INT, Literal: "1"}, Value: 1} }
+	two := func() Expression { return &IntegerLiteral{Token: lexer.Token{Type: lexer.INT, Literal: "2"}, Value: 2} }
+
+	turnOneIntoTwo := func(node Node) Node {
+		integer, ok := node.(*IntegerLiteral)
+		if !ok || integer.Value != 1 {
+			return node
+		}
+
+		return two()
+	}
+
+	ident := func(name string) *Identifier { return &Identifier{Value: name} }
+	block := func(expression Expression) *BlockStatement {
+		return &BlockStatement{Statements: []Statement{&ExpressionStatement{Expression: expression}}}
+	}
+
+	tests := []struct {
+		input    Node
+		expected Node
+	}{
+		{one(), two()},
+		{
+			&Program{Statements: []Statement{&ExpressionStatement{Expression: one()}}},
+			&Program{Statements: []Statement{&ExpressionStatement{Expression: two()}}},
+		},
+		{
+			&InfixExpression{Left: one(), Operator: "+", Right: two()},
+			&InfixExpression{Left: two(), Operator: "+", Right: two()},
+		},
+		{
+			&PrefixExpression{Operator: "-", Right: one()},
+			&PrefixExpression{Operator: "-", Right: two()},
+		},
+		{
+			&IndexExpression{Left: one(), Index: one()},
+			&IndexExpression{Left: two(), Index: two()},
+		},
+		{
+			&SliceExpression{Left: one(), End: one()},
+			&SliceExpression{Left: two(), End: two()},
+		},
+		{
+			&CallExpression{Function: ident("f"), Arguments: []Expression{one(), ident("x")}},
+			&CallExpression{Function: ident("f"), Arguments: []Expression{two(), ident("x")}},
+		},
+		{
+			&IfExpression{Condition: one(), Consequence: block(one()), Alternative: block(one())},
+			&IfExpression{Condition: two(), Consequence: block(two()), Alternative: block(two())},
+		},
+		{
+			&ReturnStatement{ReturnValue: one()},
+			&ReturnStatement{ReturnValue: two()},
+		},
+		{
+			&LetStatement{Name: ident("x"), Value: one()},
+			&LetStatement{Name: ident("x"), Value: two()},
+		},
+		{
+			&FunctionLiteral{Parameters: []*Identifier{ident("x")}, Body: block(one())},
+			&FunctionLiteral{Parameters: []*Identifier{ident("x")}, Body: block(two())},
+		},
+		{
+			&ListLiteral{Elements: []Expression{one(), one()}},
+			&ListLiteral{Elements: []Expression{two(), two()}},
+		},
+		{
+			&MatchExpression{Subject: one(), Arms: []*MatchArm{{Pattern: &LiteralPattern{Value: one()}, Guard: one(), Body: block(one())}}},
+			&MatchExpression{Subject: two(), Arms: []*MatchArm{{Pattern: &LiteralPattern{Value: two()}, Guard: two(), Body: block(two())}}},
+		},
+		{
+			&LetStatement{Pattern: &ListPattern{Elements: []Pattern{&DefaultPattern{Pattern: &BindingPattern{Name: ident("a")}, Default: one()}}}, Value: one()},
+			&LetStatement{Pattern: &ListPattern{Elements: []Pattern{&DefaultPattern{Pattern: &BindingPattern{Name: ident("a")}, Default: two()}}}, Value: two()},
+		},
+	}
+
+	for _, tt := range tests {
+		modified := Modify(tt.input, turnOneIntoTwo)
+
+		if !reflect.DeepEqual(modified, tt.expected) {
+			t.Errorf("not equal. got=%#v, want=%#v", modified, tt.expected)
+		}
+	}
+
+	// Map literals are modified in their keys and values
+	mapLiteral := &MapLiteral{Pairs: map[Expression]Expression{one(): one()}}
+	modified := Modify(mapLiteral, turnOneIntoTwo).(*MapLiteral)
+	for key, value := range modified.Pairs {
+		if key.(*IntegerLiteral).Value != 2 || value.(*IntegerLiteral).Value != 2 {
+			t.Errorf("map pair not modified. got=%s:%s", key, value)
+		}
+	}
+
+	// The modified tree is not mutated and nodes without replaced children are not copied
+	original := &InfixExpression{Left: one(), Operator: "+", Right: ident("x")}
+	Modify(original, turnOneIntoTwo)
+	if original.Left.(*IntegerLiteral).Value != 1 {
+		t.Errorf("original node was mutated")
+	}
+
+	unchanged := &CallExpression{Function: ident("f"), Arguments: []Expression{two()}}
+	if Modify(unchanged, turnOneIntoTwo) != unchanged {
+		t.Errorf("node without replaced children was copied")
+	}
+
+	// Replacements of the wrong kind are ignored and member names are not visited
+	toInteger := func(node Node) Node {
+		if _, ok := node.(*Identifier); ok {
+			return two()
+		}
+		return node
+	}
+
+	function := Modify(&FunctionLiteral{Parameters: []*Identifier{ident("x")}, Body: block(ident("x"))}, toInteger).(*FunctionLiteral)
+	if function.Parameters[0].Value != "x" || function.Body.String() != "2" {
+		t.Errorf("wrong modification of function. got=%s", function)
+	}
+
+	member := Modify(&MemberExpression{Object: ident("p"), Property: ident("x")}, toInteger).(*MemberExpression)
+	if member.String() != "(2.x)" {
+		t.Errorf("wrong modification of member expression. got=%s", member)
+	}
+
+	grouped := Modify(&GroupedExpression{Expression: &MemberExpression{Object: ident("p"), Property: ident("x"), Optional: true}}, toInteger).(*GroupedExpression)
+	if grouped.String() != "((2?.x))" {
+		t.Errorf("wrong modification of grouped expression. got=%s", grouped)
+	}
+}
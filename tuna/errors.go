@@ -0,0 +1,59 @@
+package tuna
+
+import (
+	"strings"
+
+	"github.com/manishmeganathan/tunalang/object"
+)
+
+// A structure that represents an error encountered while parsing Tuna source code
+type SyntaxError struct {
+	// Represents the errors collected by the parser
+	Errors []string
+}
+
+// A method of SyntaxError that returns its error message
+func (e *SyntaxError) Error() string {
+	return "syntax error: " + strings.Join(e.Errors, "; ")
+}
+
+// A structure that represents an error encountered while running a Tuna program
+type RuntimeError struct {
+	// Represents the error message
+	Message string
+
+	// Represents the kind of the error (such as TypeError or NameError)
+	Kind string
+
+	// Represents the value thrown by a throw statement as a Go value (nil for runtime errors)
+	Value interface{}
+
+	// Represents the line and column where the error occurred (0 if unknown)
+	Line   int
+	Column int
+
+	// Represents the call stack of the error, starting with the innermost call
+	Stack []object.Frame
+}
+
+// A function that converts an Error object into a RuntimeError
+func newRuntimeError(err *object.Error) *RuntimeError {
+	runtimeErr := &RuntimeError{Message: err.Message, Kind: err.Kind, Line: err.Line, Column: err.Column, Stack: err.Stack}
+	// Convert the thrown value into a Go value
+	if err.Value != nil {
+		runtimeErr.Value = object.ToGo(err.Value)
+	}
+
+	return runtimeErr
+}
+
+// A method of RuntimeError that returns its error message
+func (e *RuntimeError) Error() string {
+	return "runtime error: " + e.Message
+}
+
+// A method of RuntimeError that returns a traceback of the error
+func (e *RuntimeError) Traceback() string {
+	err := &object.Error{Message: e.Message, Kind: e.Kind, Line: e.Line, Column: e.Column, Stack: e.Stack}
+	return err.Traceback()
+}
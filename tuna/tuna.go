@@ -0,0 +1,223 @@
+// Package tuna provides the public API for embedding the Tuna
+// programming language in Go programs. Source code can be evaluated
+// directly with Eval or compiled once with Compile and run many times.
+package tuna
+
+import (
+	"context"
+	"io"
+
+	"github.com/manishmeganathan/tunalang/evaluator"
+	"github.com/manishmeganathan/tunalang/lexer"
+	"github.com/manishmeganathan/tunalang/object"
+	"github.com/manishmeganathan/tunalang/parser"
+	"github.com/manishmeganathan/tunalang/syntaxtree"
+)
+
+// Represents the signature of a Go function that can be injected as a global. Any other Go
+// function can also be injected, its arguments and results are converted with reflection.
+type Function func(args ...interface{}) (interface{}, error)
+
+// A structure that represents the options for running Tuna programs
+type Options struct {
+	// Represents the Go values and functions injected as globals
+	Globals map[string]interface{}
+
+	// Represents the writer used for standard output (defaults to os.Stdout)
+	Stdout io.Writer
+
+	// Represents the writer used for standard error (defaults to os.Stderr)
+	Stderr io.Writer
+
+	// Represents the reader used for standard input (defaults to os.Stdin)
+	Stdin io.Reader
+
+	// Represents the resource limits of the interpreter
+	Limits evaluator.Limits
+
+	// Represents whether programs run in strict mode
+	Strict bool
+
+	// Represents the directories searched for imported modules (module imports are disabled if it is empty)
+	ModulePaths []string
+}
+
+// A structure that represents a compiled Tuna program
+type Program struct {
+	// Represents the source code of the program
+	Source string
+
+	// Represents the parsed syntax tree of the program
+	tree *syntaxtree.Program
+}
+
+// A structure that represents an execution environment for Tuna programs.
+// Bindings created by a program are retained across runs on the same Env.
+type Env struct {
+	// Represents the interpreter used to run programs
+	interpreter *evaluator.Interpreter
+
+	// Represents the global scope of the environment
+	scope *object.Environment
+}
+
+// A function that compiles Tuna source code into a Program. The
+// returned error is a *SyntaxError if the source could not be parsed.
+func Compile(src string) (*Program, error) {
+	// Create a Parser for the source
+	par := parser.NewParser(lexer.NewLexer(src))
+	// Parse the source into a syntax tree
+	tree := par.ParseProgram()
+
+	// Check for parser errors
+	if len(par.Errors) != 0 {
+		return nil, &SyntaxError{Errors: par.Errors}
+	}
+
+	// Return the compiled program
+	return &Program{Source: src, tree: tree}, nil
+}
+
+// A function that evaluates Tuna source code with the given options (which
+// may be nil) and returns the result of the program as a Go value
+func Eval(src string, opts *Options) (interface{}, error) {
+	// Compile the source into a program
+	prog, err := Compile(src)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create an environment for the options
+	env, err := NewEnv(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run the program in the environment
+	return prog.Run(env)
+}
+
+// A constructor function that generates and returns a new Env for the given options
+// (which may be nil). An error is returned if a global cannot be converted.
+func NewEnv(opts *Options) (*Env, error) {
+	// Default to the zero options
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	// Collect the interpreter configuration options
+	config := []evaluator.Option{evaluator.WithLimits(opts.Limits), evaluator.WithStrictMode(opts.Strict)}
+	if opts.Stdout != nil {
+		config = append(config, evaluator.WithStdout(opts.Stdout))
+	}
+	if opts.Stderr != nil {
+		config = append(config, evaluator.WithStderr(opts.Stderr))
+	}
+	if opts.Stdin != nil {
+		config = append(config, evaluator.WithStdin(opts.Stdin))
+	}
+	if opts.ModulePaths != nil {
+		config = append(config, evaluator.WithModulePaths(opts.ModulePaths...))
+	}
+
+	// Create the environment
+	env := &Env{
+		interpreter: evaluator.NewInterpreter(config...),
+		scope:       object.NewEnvironment(),
+	}
+
+	// Inject the globals into the environment
+	for name, value := range opts.Globals {
+		if err := env.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return the environment
+	return env, nil
+}
+
+// A method of Env that converts a Go value into a Tuna object and binds it to the given name.
+// The supported values are described by the object.FromGo function. It is safe for concurrent
+// use with running programs and may be called from Go functions invoked by a running program.
+func (e *Env) Set(name string, value interface{}) error {
+	// Convert the value into an object
+	obj, err := object.FromGo(value)
+	if err != nil {
+		return err
+	}
+
+	// Bind the object to the name while holding the lock of the interpreter
+	var result object.Object
+	e.interpreter.Exclusive(func() { result = e.scope.Set(name, obj) })
+
+	// Check if the binding failed (the name is a constant)
+	if errObj, ok := result.(*object.Error); ok {
+		return newRuntimeError(errObj)
+	}
+
+	return nil
+}
+
+// A method of Env that retrieves the value bound to a given name and converts it into
+// a Go value. It is safe for concurrent use in the same way as the Set method.
+func (e *Env) Get(name string) (interface{}, bool) {
+	// Retrieve the object from the scope while holding the lock of the interpreter
+	var obj object.Object
+	var ok bool
+	e.interpreter.Exclusive(func() { obj, ok = e.scope.Get(name) })
+	if !ok {
+		return nil, false
+	}
+
+	// Convert the object into a Go value
+	return object.ToGo(obj), true
+}
+
+// A method of Program that runs it in the given environment (a new one with the default
+// options is created if it is nil) and returns the result of the program as a Go value
+func (p *Program) Run(env *Env) (interface{}, error) {
+	// Create a default environment if required
+	if env == nil {
+		env, _ = NewEnv(nil)
+	}
+
+	// Evaluate the program
+	result := env.interpreter.Evaluate(p.tree, env.scope)
+
+	// Check if the program resulted in an error
+	if errObj, ok := result.(*object.Error); ok {
+		return nil, newRuntimeError(errObj)
+	}
+
+	// Convert the result into a Go value
+	return object.ToGo(result), nil
+}
+
+// A method of Env that calls a callable Tuna object (such as a function returned by a program) with
+// the given Go arguments and returns its result as a Go value. It is safe for concurrent use and may
+// be called from Go functions invoked by a running program. The call is aborted if ctx is cancelled.
+func (e *Env) Call(ctx context.Context, fn object.Object, args ...interface{}) (interface{}, error) {
+	// Convert the arguments into objects
+	objs := make([]object.Object, len(args))
+	for idx, arg := range args {
+		obj, err := object.FromGo(arg)
+		if err != nil {
+			return nil, err
+		}
+		objs[idx] = obj
+	}
+
+	// Call the function with the interpreter
+	result, err := e.interpreter.Call(ctx, fn, objs...)
+	if err != nil {
+		// Convert runtime errors into a RuntimeError
+		if errObj, ok := err.(*object.Error); ok {
+			return nil, newRuntimeError(errObj)
+		}
+		return nil, err
+	}
+
+	// Convert the result into a Go value
+	return object.ToGo(result), nil
+}
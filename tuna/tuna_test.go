@@ -0,0 +1,286 @@
+package tuna
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/manishmeganathan/tunalang/object"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`5 + 5`, int64(10)},
+		{`"hello" + " " + "world"`, "hello world"},
+		{`1 < 2`, true},
+		{`if (false) { 1 }`, nil},
+		{`[1, "two", true]`, []interface{}{int64(1), "two", true}},
+		{`{"a": 1, 2: "b"}`, map[interface{}]interface{}{"a": int64(1), int64(2): "b"}},
+	}
+
+	for _, tt := range tests {
+		result, err := Eval(tt.input, nil)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", tt.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("wrong result for %q. expected=%#v, got=%#v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestEvalImportsDisabled(t *testing.T) {
+	_, err := Eval(`import "/etc/hostname" as h;`, nil)
+	if err == nil || err.Error() != "runtime error: module imports are disabled" {
+		t.Errorf("wrong error. got=%v", err)
+	}
+}
+
+func TestEvalGlobals(t *testing.T) {
+	var out bytes.Buffer
+	opts := &Options{
+		Stdout: &out,
+		Globals: map[string]interface{}{
+			"name":  "tuna",
+			"limit": 3,
+			"items": []interface{}{1, 2, 3},
+			"user":  map[string]interface{}{"admin": true},
+			"double": Function(func(args ...interface{}) (interface{}, error) {
+				return args[0].(int64) * 2, nil
+			}),
+			"fail": func(args ...interface{}) (interface{}, error) {
+				return nil, errors.New("host failure")
+			},
+		},
+	}
+
+	result, err := Eval(`puts(name); if (user["admin"]) { double(limit) + len(items) }`, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result != int64(9) {
+		t.Errorf("wrong result. expected=9, got=%#v", result)
+	}
+
+	if out.String() != "tuna\n" {
+		t.Errorf("wrong output. got=%q", out.String())
+	}
+
+	_, err = Eval(`fail()`, opts)
+	runtimeErr, ok := err.(*RuntimeError)
+	if !ok {
+		t.Fatalf("error is not RuntimeError. got=%T (%v)", err, err)
+	}
+
+	if runtimeErr.Message != "host failure" {
+		t.Errorf("wrong error message. got=%q", runtimeErr.Message)
+	}
+
+	_, err = Eval(`1`, &Options{Globals: map[string]interface{}{"bad": 3.14}})
+	if err == nil {
+		t.Errorf("expected an error for an unsupported global")
+	}
+}
+
+func TestCompileAndRun(t *testing.T) {
+	_, err := Compile(`let = 5;`)
+	syntaxErr, ok := err.(*SyntaxError)
+	if !ok {
+		t.Fatalf("error is not SyntaxError. got=%T (%v)", err, err)
+	}
+
+	if len(syntaxErr.Errors) == 0 {
+		t.Errorf("syntax error has no parser errors")
+	}
+
+	prog, err := Compile(`let counter = counter + 1; counter`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	env, err := NewEnv(&Options{Globals: map[string]interface{}{"counter": 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for expected := int64(1); expected <= 3; expected++ {
+		result, err := prog.Run(env)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if result != expected {
+			t.Errorf("wrong result. expected=%d, got=%#v", expected, result)
+		}
+	}
+
+	if value, ok := env.Get("counter"); !ok || value != int64(3) {
+		t.Errorf("wrong value for counter. got=%#v", value)
+	}
+
+	_, err = prog.Run(nil)
+	if _, ok := err.(*RuntimeError); !ok {
+		t.Errorf("error is not RuntimeError. got=%T (%v)", err, err)
+	}
+}
+
+func TestStrictProgramRunTwice(t *testing.T) {
+	env, err := NewEnv(&Options{Strict: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	prog, err := Compile(`let x = 1; x + 1`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// A strict program can be run many times on the same environment
+	for i := 0; i < 2; i++ {
+		if result, err := prog.Run(env); err != nil || result != int64(2) {
+			t.Errorf("wrong result of run %d. got=%#v (%v)", i+1, result, err)
+		}
+	}
+}
+
+func TestEvalReflectedGlobals(t *testing.T) {
+	type point struct {
+		X int `tuna:"x"`
+		Y int `tuna:"y"`
+	}
+
+	opts := &Options{Globals: map[string]interface{}{
+		"origin": point{X: 1, Y: 2},
+		"scale": func(p point, factor int) point {
+			return point{X: p.X * factor, Y: p.Y * factor}
+		},
+		"parse": func(s string) (int, error) {
+			return 0, errors.New("cannot parse " + s)
+		},
+	}}
+
+	result, err := Eval(`scale(origin, 3)["y"]`, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result != int64(6) {
+		t.Errorf("wrong result. expected=6, got=%#v", result)
+	}
+
+	_, err = Eval(`parse("x")`, opts)
+	if err == nil || err.Error() != "runtime error: cannot parse x" {
+		t.Errorf("wrong error. got=%v", err)
+	}
+}
+
+func TestEnvCall(t *testing.T) {
+	var handlers []object.Object
+	env, err := NewEnv(&Options{Globals: map[string]interface{}{
+		"on": func(handler object.Object) { handlers = append(handlers, handler) },
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	prog, _ := Compile(`on(fn(name, count) { name + ": " + "ok" }); on(fn(name, count) { count * 2 }); on(fn() { oops })`)
+	if _, err := prog.Run(env); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(handlers) != 3 {
+		t.Fatalf("wrong number of handlers. got=%d", len(handlers))
+	}
+
+	result, err := env.Call(context.Background(), handlers[0], "event", 4)
+	if err != nil || result != "event: ok" {
+		t.Errorf("wrong result. got=%#v (%v)", result, err)
+	}
+
+	result, err = env.Call(context.Background(), handlers[1], "event", 4)
+	if err != nil || result != int64(8) {
+		t.Errorf("wrong result. got=%#v (%v)", result, err)
+	}
+
+	_, err = env.Call(context.Background(), handlers[2])
+	if _, ok := err.(*RuntimeError); !ok {
+		t.Errorf("error is not RuntimeError. got=%T (%v)", err, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = env.Call(ctx, handlers[0], "event", 4); err != context.Canceled {
+		t.Errorf("wrong error. expected=%v, got=%v", context.Canceled, err)
+	}
+}
+
+func TestEnvConcurrentAccess(t *testing.T) {
+	env, err := NewEnv(&Options{Globals: map[string]interface{}{"step": 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	prog, _ := Compile(`fn(n) { let count = fn(i, acc) { if (i == 0) { acc } else { count(i - 1, acc + step) } }; count(n, 0) }`)
+	counter, err := prog.Run(env)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Rebind and read the globals while the function is called (run with -race)
+	var wg sync.WaitGroup
+	for idx := 0; idx < 4; idx++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := env.Call(context.Background(), counter.(object.Object), 200); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+		go func(idx int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if err := env.Set(fmt.Sprintf("global%d", idx), i); err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				env.Set("step", 1)
+				env.Get("step")
+			}
+		}(idx)
+	}
+	wg.Wait()
+
+	if value, ok := env.Get("global0"); !ok || value != int64(99) {
+		t.Errorf("wrong value of global. got=%#v", value)
+	}
+}
+
+func TestRuntimeErrorStack(t *testing.T) {
+	_, err := Eval("let check = fn(x) { if (x > 1) { x + true } else { check(x + 1) } };\ncheck(0)", nil)
+	runtimeErr, ok := err.(*RuntimeError)
+	if !ok {
+		t.Fatalf("error is not RuntimeError. got=%T (%v)", err, err)
+	}
+
+	if runtimeErr.Line != 1 || runtimeErr.Column != 36 {
+		t.Errorf("wrong error position. expected=1:36, got=%d:%d", runtimeErr.Line, runtimeErr.Column)
+	}
+
+	if len(runtimeErr.Stack) != 3 {
+		t.Errorf("wrong stack length. expected=3, got=%d", len(runtimeErr.Stack))
+	}
+
+	if !strings.HasSuffix(runtimeErr.Traceback(), "in check\nERROR: type mismatch: INTEGER + BOOLEAN") {
+		t.Errorf("wrong traceback. got=%q", runtimeErr.Traceback())
+	}
+}